@@ -0,0 +1,145 @@
+package set
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Set[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Set[int])(nil)
+)
+
+// errTruncated is returned when binary data ends in the middle of a set.
+var errTruncated = errors.New("truncated data")
+
+// MarshalBinary marshals s into a compact binary form. The data starts with
+// the number of elements as an unsigned varint, followed by the elements.
+// Booleans are written as a single byte, signed and unsigned integers as
+// varints, floats as their little-endian IEEE 754 bits and strings as their
+// length as an unsigned varint followed by their bytes. Elements of any other
+// kind are not supported.
+func (s Set[T]) MarshalBinary() ([]byte, error) {
+	data := binary.AppendUvarint(nil, uint64(len(s.m)))
+	for v := range s.m {
+		var err error
+		if data, err = appendBinaryElem(data, reflect.ValueOf(v)); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary unmarshals data, in the format written by MarshalBinary,
+// into s. The previous content of s is cleared.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	s.Clear()
+	n, k := binary.Uvarint(data)
+	if k <= 0 {
+		return &DecodeError{Format: "binary", Index: -1, Err: errTruncated}
+	}
+	data = data[k:]
+	// Every element occupies at least one byte, which bounds the length
+	// prefix and prevents huge allocations for corrupt data.
+	if n > uint64(len(data)) {
+		return &DecodeError{Format: "binary", Index: -1, Err: errTruncated}
+	}
+	for i := 0; i < int(n); i++ {
+		var v T
+		var err error
+		if data, err = readBinaryElem(data, reflect.ValueOf(&v).Elem()); err != nil {
+			return &DecodeError{Format: "binary", Index: i, Err: err}
+		}
+		s.Append(v)
+	}
+	if len(data) != 0 {
+		return &DecodeError{Format: "binary", Index: -1, Err: errors.New("trailing data")}
+	}
+	return nil
+}
+
+// appendBinaryElem appends the binary form of v to data.
+func appendBinaryElem(data []byte, v reflect.Value) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(data, 1), nil
+		}
+		return append(data, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(data, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(data, v.Uint()), nil
+	case reflect.Float32:
+		return binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(v.Float()))), nil
+	case reflect.Float64:
+		return binary.LittleEndian.AppendUint64(data, math.Float64bits(v.Float())), nil
+	case reflect.String:
+		data = binary.AppendUvarint(data, uint64(v.Len()))
+		return append(data, v.String()...), nil
+	default:
+		return nil, fmt.Errorf("set: unsupported binary element type %s", v.Type())
+	}
+}
+
+// readBinaryElem reads the binary form of a single element from data into v
+// and returns the remaining data.
+func readBinaryElem(data []byte, v reflect.Value) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if len(data) < 1 {
+			return nil, errTruncated
+		}
+		if data[0] > 1 {
+			return nil, fmt.Errorf("invalid boolean %d", data[0])
+		}
+		v.SetBool(data[0] == 1)
+		return data[1:], nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, k := binary.Varint(data)
+		if k <= 0 {
+			return nil, errTruncated
+		}
+		if v.OverflowInt(x) {
+			return nil, fmt.Errorf("value %d overflows %s", x, v.Type())
+		}
+		v.SetInt(x)
+		return data[k:], nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, k := binary.Uvarint(data)
+		if k <= 0 {
+			return nil, errTruncated
+		}
+		if v.OverflowUint(x) {
+			return nil, fmt.Errorf("value %d overflows %s", x, v.Type())
+		}
+		v.SetUint(x)
+		return data[k:], nil
+	case reflect.Float32:
+		if len(data) < 4 {
+			return nil, errTruncated
+		}
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data))))
+		return data[4:], nil
+	case reflect.Float64:
+		if len(data) < 8 {
+			return nil, errTruncated
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		return data[8:], nil
+	case reflect.String:
+		n, k := binary.Uvarint(data)
+		if k <= 0 || n > uint64(len(data)-k) {
+			return nil, errTruncated
+		}
+		data = data[k:]
+		v.SetString(string(data[:n]))
+		return data[n:], nil
+	default:
+		return nil, fmt.Errorf("unsupported element type %s", v.Type())
+	}
+}
@@ -0,0 +1,76 @@
+package set
+
+import (
+	"errors"
+	"testing"
+)
+
+func testBinaryRoundTrip[T comparable](t *testing.T, s Set[T]) {
+	t.Helper()
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Set[T]
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(s) {
+		t.Errorf("UnmarshalBinary() = %v, want %v", got, s)
+	}
+}
+
+func TestSet_BinaryRoundTrip(t *testing.T) {
+	testBinaryRoundTrip(t, Of(true, false))
+	testBinaryRoundTrip(t, Of(-1, 0, 1<<40))
+	testBinaryRoundTrip(t, Of[int8](-128, 127))
+	testBinaryRoundTrip(t, Of[uint16](0, 65535))
+	testBinaryRoundTrip(t, Of[float32](1.5, -2))
+	testBinaryRoundTrip(t, Of(3.25, -0.5))
+	testBinaryRoundTrip(t, Of("", "a", "héllo"))
+	testBinaryRoundTrip(t, Empty[string]())
+}
+
+func TestSet_MarshalBinaryUnsupported(t *testing.T) {
+	type point struct{ X, Y int }
+	if _, err := Of(point{1, 2}).MarshalBinary(); err == nil {
+		t.Error("MarshalBinary() of a struct set must fail")
+	}
+}
+
+func TestSet_UnmarshalBinaryErrors(t *testing.T) {
+	valid, err := Of("abc").MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		data  []byte
+		index int
+	}{
+		{"empty", nil, -1},
+		{"huge length", []byte{0xff, 0xff, 0x03}, -1},
+		{"truncated element", valid[:len(valid)-1], 0},
+		{"trailing data", append(valid, 0), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Set[string]
+			err := s.UnmarshalBinary(tt.data)
+			var de *DecodeError
+			if !errors.As(err, &de) || de.Format != "binary" || de.Index != tt.index {
+				t.Errorf("UnmarshalBinary() error = %v, want binary DecodeError at index %d", err, tt.index)
+			}
+		})
+	}
+
+	var small Set[int8]
+	data, _ := Of(1000).MarshalBinary()
+	if err := small.UnmarshalBinary(data); err == nil {
+		t.Error("UnmarshalBinary() must reject values that overflow the element type")
+	}
+	var bools Set[bool]
+	if err := bools.UnmarshalBinary([]byte{1, 2}); err == nil {
+		t.Error("UnmarshalBinary() must reject invalid booleans")
+	}
+}
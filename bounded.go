@@ -0,0 +1,92 @@
+package set
+
+import "iter"
+
+// OverflowPolicy determines what a BoundedSet does when a new element is
+// added while it is full.
+type OverflowPolicy int
+
+const (
+	// RejectNew leaves a full set unchanged and rejects the new element.
+	RejectNew OverflowPolicy = iota
+
+	// EvictArbitrary removes an arbitrary element from a full set to make
+	// room for the new element.
+	EvictArbitrary
+)
+
+// BoundedSet is a Set that never holds more than a fixed number of elements.
+// What happens when a new element is added to a full set is determined by
+// its OverflowPolicy.
+type BoundedSet[T comparable] struct {
+	// s is the bounded set.
+	s Set[T]
+
+	// max is the maximum number of elements of s.
+	max int
+
+	// policy determines how to add elements once s holds max elements.
+	policy OverflowPolicy
+}
+
+// EmptyBounded initializes a new BoundedSet without any elements inside it
+// that holds at most max elements. It panics if max is negative.
+func EmptyBounded[T comparable](max int, policy OverflowPolicy) *BoundedSet[T] {
+	if max < 0 {
+		panic("set: negative BoundedSet capacity")
+	}
+	return &BoundedSet[T]{s: WithCapacity[T](max), max: max, policy: policy}
+}
+
+// Add adds val to s and reports whether val is present afterwards. If val is
+// already present, Add succeeds without growing s. Otherwise, if s is full,
+// val is rejected under RejectNew, whereas under EvictArbitrary an arbitrary
+// element is removed first to make room for val.
+func (s *BoundedSet[T]) Add(val T) bool {
+	if s.s.Contains(val) {
+		return true
+	}
+	if s.s.Len() >= s.max {
+		if s.policy != EvictArbitrary || s.max == 0 {
+			return false
+		}
+		s.s.Pop()
+	}
+	return s.s.Add(val)
+}
+
+// Contains reports whether s contains val.
+func (s *BoundedSet[T]) Contains(val T) bool {
+	return s.s.Contains(val)
+}
+
+// Len returns the number of elements that s contains.
+func (s *BoundedSet[T]) Len() int {
+	return s.s.Len()
+}
+
+// Cap returns the maximum number of elements that s can hold.
+func (s *BoundedSet[T]) Cap() int {
+	return s.max
+}
+
+// Delete removes the elements of values from s.
+func (s *BoundedSet[T]) Delete(values ...T) {
+	s.s.Delete(values...)
+}
+
+// Clear removes all elements from s.
+func (s *BoundedSet[T]) Clear() {
+	s.s.ClearInPlace()
+}
+
+// Slice converts s into a slice.
+func (s *BoundedSet[T]) Slice() []T {
+	return s.s.Slice()
+}
+
+// All returns an iterator over the elements of s. The iteration order is
+// unspecified.
+func (s *BoundedSet[T]) All() iter.Seq[T] {
+	return s.s.All()
+}
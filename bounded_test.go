@@ -0,0 +1,59 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestBoundedSet_RejectNew(t *testing.T) {
+	s := EmptyBounded[int](2, RejectNew)
+	if !s.Add(1) || !s.Add(2) {
+		t.Fatal("Add() below the limit must succeed")
+	}
+	if !s.Add(1) {
+		t.Error("Add() of a present value must succeed on a full set")
+	}
+	if s.Add(3) || s.Contains(3) || s.Len() != 2 {
+		t.Error("Add() on a full set must reject new values")
+	}
+	s.Delete(1)
+	if !s.Add(3) || s.Cap() != 2 {
+		t.Error("Add() must succeed after making room")
+	}
+}
+
+func TestBoundedSet_EvictArbitrary(t *testing.T) {
+	s := EmptyBounded[int](2, EvictArbitrary)
+	s.Add(1)
+	s.Add(2)
+	if !s.Add(3) || !s.Contains(3) || s.Len() != 2 {
+		t.Errorf("Slice() = %v, want 3 and one of 1 and 2", s.Slice())
+	}
+	n := 0
+	for range s.All() {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("All() yielded %d elements, want 2", n)
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", s.Len())
+	}
+}
+
+func TestBoundedSet_ZeroCapacity(t *testing.T) {
+	for _, policy := range []OverflowPolicy{RejectNew, EvictArbitrary} {
+		if EmptyBounded[int](0, policy).Add(1) {
+			t.Errorf("Add() on a set with capacity 0 and policy %d succeeded", policy)
+		}
+	}
+}
+
+func TestEmptyBounded_NegativePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("EmptyBounded(-1) did not panic")
+		}
+	}()
+	EmptyBounded[int](-1, RejectNew)
+}
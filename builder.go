@@ -0,0 +1,65 @@
+package set
+
+// builderChunk is the minimum number of elements a Builder reserves space for
+// whenever it grows its map.
+const builderChunk = 64
+
+// Builder constructs a Set in a separate mutation phase. Its zero value is
+// ready to use. Once Build is called, the builder hands off its set and
+// starts over empty.
+type Builder[T comparable] struct {
+	// s is the set under construction.
+	s Set[T]
+
+	// cap is the number of elements the map of s was allocated for.
+	cap int
+}
+
+// Add adds the values to the set under construction. The internal map is
+// grown in chunks ahead of time to reduce rehashing.
+func (b *Builder[T]) Add(values ...T) {
+	if need := b.s.Len() + len(values); b.s.m == nil || need > b.cap {
+		b.grow(need)
+	}
+	for _, v := range values {
+		b.s.m[v] = struct{}{}
+	}
+}
+
+// Len returns the number of elements that were added since the last call to
+// Build or Reset.
+func (b *Builder[T]) Len() int {
+	return b.s.Len()
+}
+
+// Build returns the constructed set and resets b. The returned set is no
+// longer referenced by b.
+func (b *Builder[T]) Build() Set[T] {
+	s := b.s
+	if s.m == nil {
+		s = Empty[T]()
+	}
+	b.Reset()
+	return s
+}
+
+// Reset discards all elements that were added since the last call to Build.
+func (b *Builder[T]) Reset() {
+	b.s, b.cap = Set[T]{}, 0
+}
+
+// grow reallocates the internal map with room for at least need elements.
+func (b *Builder[T]) grow(need int) {
+	n := 2 * b.cap
+	if n < need {
+		n = need
+	}
+	if n < builderChunk {
+		n = builderChunk
+	}
+	s := WithCapacity[T](n)
+	for v := range b.s.m {
+		s.m[v] = struct{}{}
+	}
+	b.s, b.cap = s, n
+}
@@ -0,0 +1,52 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	var b Builder[int]
+	b.Add(1, 2)
+	b.Add(2, 3)
+	if b.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", b.Len())
+	}
+	s := b.Build()
+	if !s.Equal(Of(1, 2, 3)) {
+		t.Errorf("Build() = %v, want {1, 2, 3}", s)
+	}
+
+	if b.Len() != 0 {
+		t.Errorf("Len() after Build() = %d, want 0", b.Len())
+	}
+	b.Add(4)
+	if s.Contains(4) {
+		t.Error("a built set must not be referenced by the builder")
+	}
+}
+
+func TestBuilder_Grow(t *testing.T) {
+	var b Builder[int]
+	values := sequence(3 * builderChunk)
+	for _, v := range values {
+		b.Add(v)
+	}
+	if b.cap < len(values) {
+		t.Errorf("cap = %d, want at least %d", b.cap, len(values))
+	}
+	if s := b.Build(); !s.Equal(FromSlice(values)) {
+		t.Errorf("Build() has %d elements, want %d", s.Len(), len(values))
+	}
+}
+
+func TestBuilder_EmptyAndReset(t *testing.T) {
+	var b Builder[int]
+	if s := b.Build(); s.Len() != 0 || s.m == nil {
+		t.Errorf("Build() of an empty builder = %v, want an allocated empty set", s)
+	}
+	b.Add(1)
+	b.Reset()
+	if s := b.Build(); s.Len() != 0 {
+		t.Errorf("Build() after Reset() = %v, want {}", s)
+	}
+}
@@ -0,0 +1,55 @@
+package set
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// WriteCSV writes s to w as a single CSV record with one field per element.
+// The elements are formatted with fmt.Sprint and sorted by their formatted
+// representation. Fields are quoted as required by encoding/csv. A set whose
+// only element formats as the empty string is written as a quoted empty
+// field, because an empty line is skipped by ReadCSV and would decode into an
+// empty set.
+func (s Set[T]) WriteCSV(w io.Writer) error {
+	fields := make([]string, 0, len(s.m))
+	for v := range s.m {
+		fields = append(fields, fmt.Sprint(v))
+	}
+	if len(fields) == 1 && fields[0] == "" {
+		_, err := io.WriteString(w, "\"\"\n")
+		return err
+	}
+	sort.Strings(fields)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(fields); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads the first CSV record from r and returns a Set of its fields,
+// each of which is converted into an element using parse. An input without
+// any record results in an empty set.
+func ReadCSV[T comparable](r io.Reader, parse func(string) (T, error)) (Set[T], error) {
+	s := Empty[T]()
+	record, err := csv.NewReader(r).Read()
+	if errors.Is(err, io.EOF) {
+		return s, nil
+	}
+	if err != nil {
+		return Set[T]{}, &DecodeError{Format: "csv", Index: -1, Err: err}
+	}
+	for i, field := range record {
+		v, err := parse(field)
+		if err != nil {
+			return Set[T]{}, &DecodeError{Format: "csv", Index: i, Err: err}
+		}
+		s.Append(v)
+	}
+	return s, nil
+}
@@ -0,0 +1,61 @@
+package set
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func TestSet_WriteCSV(t *testing.T) {
+	var b strings.Builder
+	if err := Of("b", "a,c", "").WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), ",\"a,c\",b\n"; got != want {
+		t.Errorf("WriteCSV() = %q, want %q", got, want)
+	}
+}
+
+func TestSet_CSVRoundTrip(t *testing.T) {
+	for _, s := range []Set[string]{
+		Empty[string](),
+		Of(""),
+		Of("", "x"),
+		Of("a", "b\"c", "d\ne"),
+	} {
+		var b strings.Builder
+		if err := s.WriteCSV(&b); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadCSV(strings.NewReader(b.String()), parseString)
+		if err != nil {
+			t.Fatalf("ReadCSV(%q) error = %v", b.String(), err)
+		}
+		if !got.Equal(s) {
+			t.Errorf("ReadCSV(%q) = %v, want %v", b.String(), got, s)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	s, err := ReadCSV(strings.NewReader("1,2,2\n3\n"), strconv.Atoi)
+	if err != nil || !s.Equal(Of(1, 2)) {
+		t.Errorf("ReadCSV() = %v, %v, want {1, 2}", s, err)
+	}
+
+	s, err = ReadCSV(strings.NewReader(""), strconv.Atoi)
+	if err != nil || s.Len() != 0 {
+		t.Errorf("ReadCSV(\"\") = %v, %v, want {}", s, err)
+	}
+
+	_, err = ReadCSV(strings.NewReader("1,x"), strconv.Atoi)
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Format != "csv" || de.Index != 1 {
+		t.Errorf("ReadCSV() error = %v, want DecodeError at index 1", err)
+	}
+}
@@ -0,0 +1,31 @@
+package set
+
+import "fmt"
+
+// DecodeError is returned when decoding a Set fails. It records the encoding
+// and, if the failure is caused by a single element, the position of that
+// element.
+type DecodeError struct {
+	// Format is the name of the encoding, e.g. "json" or "gob".
+	Format string
+
+	// Index is the position of the element that failed to decode, or -1 if
+	// the failure is not caused by a single element.
+	Index int
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns a description of e.
+func (e *DecodeError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("set: cannot decode %s: %v", e.Format, e.Err)
+	}
+	return fmt.Sprintf("set: cannot decode %s element %d: %v", e.Format, e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
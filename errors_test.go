@@ -0,0 +1,42 @@
+package set
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		err  *DecodeError
+		want string
+	}{
+		{&DecodeError{Format: "json", Index: -1, Err: io.ErrUnexpectedEOF}, "set: cannot decode json: unexpected EOF"},
+		{&DecodeError{Format: "csv", Index: 2, Err: io.ErrUnexpectedEOF}, "set: cannot decode csv element 2: unexpected EOF"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+		if !errors.Is(tt.err, io.ErrUnexpectedEOF) {
+			t.Error("DecodeError must unwrap to its underlying error")
+		}
+	}
+}
+
+func TestDecodeError_Formats(t *testing.T) {
+	var s Set[int]
+	decoders := map[string]func() error{
+		"json":   func() error { return s.UnmarshalJSON([]byte(`{`)) },
+		"gob":    func() error { return s.GobDecode(nil) },
+		"text":   func() error { return s.UnmarshalText([]byte(`x`)) },
+		"binary": func() error { return s.UnmarshalBinary(nil) },
+		"sql":    func() error { return s.Scan(1.5) },
+	}
+	for format, decode := range decoders {
+		var de *DecodeError
+		if err := decode(); !errors.As(err, &de) || de.Format != format {
+			t.Errorf("%s decoder returned %v, want a DecodeError with format %q", format, err, format)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package set
+
+import (
+	"iter"
+	"time"
+)
+
+// ExpiringSet is a data structure that contains a set of comparable elements,
+// each of which expires after a given time to live. Expired elements are
+// treated as absent and are purged lazily.
+type ExpiringSet[T comparable] struct {
+	// m is the internal map. Its keys are the elements of the set and the
+	// values are the times at which they expire.
+	m map[T]time.Time
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+// EmptyExpiring initializes a new ExpiringSet without any elements inside it.
+// The set uses now to determine the current time, which allows tests to
+// control the clock. If now is nil, time.Now is used.
+func EmptyExpiring[T comparable](now func() time.Time) *ExpiringSet[T] {
+	if now == nil {
+		now = time.Now
+	}
+	return &ExpiringSet[T]{m: make(map[T]time.Time), now: now}
+}
+
+// Add adds val to s, which expires once ttl has elapsed. If val is already
+// present, its expiry is replaced. Add reports whether val was not already
+// present.
+func (s *ExpiringSet[T]) Add(val T, ttl time.Duration) bool {
+	now := s.now()
+	exp, ok := s.m[val]
+	s.m[val] = now.Add(ttl)
+	return !ok || !now.Before(exp)
+}
+
+// Contains reports whether s contains val and val has not expired yet.
+func (s *ExpiringSet[T]) Contains(val T) bool {
+	exp, ok := s.m[val]
+	if !ok {
+		return false
+	}
+	if !s.now().Before(exp) {
+		delete(s.m, val)
+		return false
+	}
+	return true
+}
+
+// Delete removes the elements of values from s.
+func (s *ExpiringSet[T]) Delete(values ...T) {
+	for _, v := range values {
+		delete(s.m, v)
+	}
+}
+
+// Purge removes all expired elements from s.
+func (s *ExpiringSet[T]) Purge() {
+	now := s.now()
+	for v, exp := range s.m {
+		if !now.Before(exp) {
+			delete(s.m, v)
+		}
+	}
+}
+
+// Len returns the number of unexpired elements that s contains.
+func (s *ExpiringSet[T]) Len() int {
+	s.Purge()
+	return len(s.m)
+}
+
+// Slice converts the unexpired elements of s into a slice.
+func (s *ExpiringSet[T]) Slice() []T {
+	s.Purge()
+	values := make([]T, 0, len(s.m))
+	for v := range s.m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// All returns an iterator over the unexpired elements of s. The iteration
+// order is unspecified.
+func (s *ExpiringSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.Purge()
+		for v := range s.m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
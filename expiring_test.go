@@ -0,0 +1,61 @@
+package set
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for testing ExpiringSet.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func TestExpiringSet(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	s := EmptyExpiring[string](clock.now)
+
+	if !s.Add("a", time.Second) || !s.Add("b", 3*time.Second) {
+		t.Fatal("Add() of new values must report true")
+	}
+	if s.Add("a", 2*time.Second) {
+		t.Error("Add() of a present value must report false")
+	}
+
+	clock.t = clock.t.Add(time.Second)
+	if !s.Contains("a") || s.Len() != 2 {
+		t.Error("the refreshed expiry of a must be used")
+	}
+
+	clock.t = clock.t.Add(time.Second)
+	if s.Contains("a") || !s.Contains("b") || s.Len() != 1 {
+		t.Errorf("Slice() = %v, want [b]", s.Slice())
+	}
+	if !s.Add("a", time.Second) {
+		t.Error("Add() of an expired value must report true")
+	}
+
+	clock.t = clock.t.Add(10 * time.Second)
+	if s.Len() != 0 || len(s.Slice()) != 0 {
+		t.Errorf("Slice() = %v, want []", s.Slice())
+	}
+}
+
+func TestExpiringSet_PurgeAndDelete(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	s := EmptyExpiring[int](clock.now)
+	s.Add(1, time.Second)
+	s.Add(2, time.Minute)
+	s.Add(3, time.Minute)
+	s.Delete(3)
+
+	clock.t = clock.t.Add(time.Second)
+	s.Purge()
+	if len(s.m) != 1 {
+		t.Errorf("Purge() left %d entries, want 1", len(s.m))
+	}
+	for v := range s.All() {
+		if v != 2 {
+			t.Errorf("All() yielded %d, want only 2", v)
+		}
+	}
+}
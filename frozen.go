@@ -0,0 +1,92 @@
+package set
+
+import "iter"
+
+// FrozenSet is an immutable Set. It only provides read operations, so it can
+// be shared between goroutines without synchronization. Set operations on
+// frozen sets produce new frozen sets.
+type FrozenSet[T comparable] struct {
+	// s is the frozen set. It is never mutated after Freeze returns.
+	s Set[T]
+}
+
+// Freeze returns a FrozenSet that contains the elements of s. The elements
+// are copied, so later mutations to s do not affect the returned set.
+func Freeze[T comparable](s Set[T]) FrozenSet[T] {
+	return FrozenSet[T]{s: s.Clone()}
+}
+
+// Len returns the number of elements that f contains.
+func (f FrozenSet[T]) Len() int {
+	return f.s.Len()
+}
+
+// Contains reports whether f contains val.
+func (f FrozenSet[T]) Contains(val T) bool {
+	return f.s.Contains(val)
+}
+
+// Slice converts f into a slice.
+func (f FrozenSet[T]) Slice() []T {
+	return f.s.Slice()
+}
+
+// All returns an iterator over the elements of f. The iteration order is
+// unspecified.
+func (f FrozenSet[T]) All() iter.Seq[T] {
+	return f.s.All()
+}
+
+// Set returns a new mutable Set that contains the elements of f.
+func (f FrozenSet[T]) Set() Set[T] {
+	return f.s.Clone()
+}
+
+// Equal reports whether f and other contain exactly the same elements.
+func (f FrozenSet[T]) Equal(other FrozenSet[T]) bool {
+	return f.s.Equal(other.s)
+}
+
+// IsSubset reports whether every element of f is present in other.
+func (f FrozenSet[T]) IsSubset(other FrozenSet[T]) bool {
+	return f.s.IsSubset(other.s)
+}
+
+// IsSuperset reports whether every element of other is present in f.
+func (f FrozenSet[T]) IsSuperset(other FrozenSet[T]) bool {
+	return f.s.IsSuperset(other.s)
+}
+
+// IsDisjoint reports whether f and other have no elements in common.
+func (f FrozenSet[T]) IsDisjoint(other FrozenSet[T]) bool {
+	return f.s.IsDisjoint(other.s)
+}
+
+// Union returns a new FrozenSet that contains every element that is present
+// in f or other.
+func (f FrozenSet[T]) Union(other FrozenSet[T]) FrozenSet[T] {
+	return FrozenSet[T]{s: f.s.Union(other.s)}
+}
+
+// Intersection returns a new FrozenSet that contains only the elements that
+// are present in both f and other.
+func (f FrozenSet[T]) Intersection(other FrozenSet[T]) FrozenSet[T] {
+	return FrozenSet[T]{s: f.s.Intersection(other.s)}
+}
+
+// Difference returns a new FrozenSet that contains the elements of f that are
+// not present in other.
+func (f FrozenSet[T]) Difference(other FrozenSet[T]) FrozenSet[T] {
+	return FrozenSet[T]{s: f.s.Difference(other.s)}
+}
+
+// SymmetricDifference returns a new FrozenSet that contains the elements that
+// are present in exactly one of f and other.
+func (f FrozenSet[T]) SymmetricDifference(other FrozenSet[T]) FrozenSet[T] {
+	return FrozenSet[T]{s: f.s.SymmetricDifference(other.s)}
+}
+
+// String formats f like Set.String.
+func (f FrozenSet[T]) String() string {
+	return f.s.String()
+}
@@ -0,0 +1,52 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	s := Of(1, 2)
+	f := Freeze(s)
+	s.Append(3)
+	if f.Len() != 2 || f.Contains(3) {
+		t.Errorf("Freeze() must copy the elements, got %v", f)
+	}
+
+	m := f.Set()
+	m.Append(4)
+	if f.Contains(4) {
+		t.Error("Set() must return an independent copy")
+	}
+	if f.String() != "set{1, 2}" || len(f.Slice()) != 2 {
+		t.Errorf("String() = %q, want %q", f.String(), "set{1, 2}")
+	}
+}
+
+func TestFrozenSet_Operations(t *testing.T) {
+	a, b := Freeze(Of(1, 2, 3)), Freeze(Of(2, 3, 4))
+	tests := []struct {
+		name string
+		got  FrozenSet[int]
+		want Set[int]
+	}{
+		{"Union", a.Union(b), Of(1, 2, 3, 4)},
+		{"Intersection", a.Intersection(b), Of(2, 3)},
+		{"Difference", a.Difference(b), Of(1)},
+		{"SymmetricDifference", a.SymmetricDifference(b), Of(1, 4)},
+	}
+	for _, tt := range tests {
+		if !tt.got.Equal(Freeze(tt.want)) {
+			t.Errorf("%s() = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if a.IsSubset(b) || !a.IsSuperset(Freeze(Of(1))) || a.IsDisjoint(b) {
+		t.Error("relations between frozen sets")
+	}
+	n := 0
+	for range a.All() {
+		n++
+	}
+	if n != 3 {
+		t.Errorf("All() yielded %d elements, want 3", n)
+	}
+}
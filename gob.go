@@ -0,0 +1,35 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+var (
+	_ gob.GobEncoder = (*Set[int])(nil)
+	_ gob.GobDecoder = (*Set[int])(nil)
+)
+
+// GobEncode encodes s as a gob-encoded slice of its elements.
+//
+// Like other empty values, an empty set stored in a struct field is not
+// transmitted by gob, so decoding leaves the destination field unchanged.
+func (s Set[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.Slice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes a gob-encoded slice of elements into s. The previous
+// content of s is cleared.
+func (s *Set[T]) GobDecode(data []byte) error {
+	s.Clear()
+	var values []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return &DecodeError{Format: "gob", Index: -1, Err: err}
+	}
+	s.Append(values...)
+	return nil
+}
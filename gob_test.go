@@ -0,0 +1,64 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestSet_GobRoundTrip(t *testing.T) {
+	type wrapper struct{ S Set[string] }
+	in := wrapper{S: Of("a", "b", "c")}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	out := wrapper{S: Of("stale")}
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.S.Equal(in.S) {
+		t.Errorf("decoded %v, want %v", out.S, in.S)
+	}
+}
+
+func TestSet_GobDecodeError(t *testing.T) {
+	var s Set[int]
+	err := s.GobDecode([]byte("garbage"))
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Format != "gob" {
+		t.Errorf("GobDecode() error = %v, want gob DecodeError", err)
+	}
+}
+
+func TestSet_GobRoundTripEmpty(t *testing.T) {
+	for _, in := range []Set[int]{Empty[int](), {}} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			t.Fatal(err)
+		}
+		out := Of(1)
+		if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		if out.Len() != 0 {
+			t.Errorf("decoded %v, want {}", out)
+		}
+	}
+
+	// Gob omits empty struct fields, so the destination field keeps its value.
+	type wrapper struct{ S Set[int] }
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(wrapper{}); err != nil {
+		t.Fatal(err)
+	}
+	out := wrapper{S: Of(1)}
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.S.Equal(Of(1)) {
+		t.Errorf("decoded %v, want {1}", out.S)
+	}
+}
@@ -0,0 +1,49 @@
+package set
+
+// Interner deduplicates equal values by handing out the instance of each
+// value that was stored first. Interning equal strings, for example, makes
+// them share the backing array of the first stored instance. Its zero value
+// is ready to use.
+type Interner[T comparable] struct {
+	// m maps every interned value to the instance of it that was stored
+	// first. It is nil until the first value is interned.
+	m map[T]T
+}
+
+// EmptyInterner initializes a new Interner without any values inside it.
+func EmptyInterner[T comparable]() *Interner[T] {
+	return &Interner[T]{m: make(map[T]T)}
+}
+
+// Intern returns the instance of val that is stored in i. If val is not
+// present, it is stored and returned as is.
+func (i *Interner[T]) Intern(val T) T {
+	if stored, ok := i.m[val]; ok {
+		return stored
+	}
+	if i.m == nil {
+		i.m = make(map[T]T)
+	}
+	i.m[val] = val
+	return val
+}
+
+// Contains reports whether val has been interned by i.
+func (i *Interner[T]) Contains(val T) bool {
+	_, ok := i.m[val]
+	return ok
+}
+
+// Len returns the number of distinct values that were interned by i.
+func (i *Interner[T]) Len() int {
+	return len(i.m)
+}
+
+// Set returns a new Set that contains the values interned by i.
+func (i *Interner[T]) Set() Set[T] {
+	s := WithCapacity[T](len(i.m))
+	for v := range i.m {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
@@ -0,0 +1,39 @@
+package set
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestInterner_Intern(t *testing.T) {
+	var i Interner[string]
+	a := strings.Repeat("ab", 4)
+	b := strings.Repeat("ab", 4)
+	if unsafe.StringData(a) == unsafe.StringData(b) {
+		t.Fatal("test strings unexpectedly share storage")
+	}
+
+	if got := i.Intern(a); unsafe.StringData(got) != unsafe.StringData(a) {
+		t.Error("Intern of a new value must return it as is")
+	}
+	if got := i.Intern(b); got != b || unsafe.StringData(got) != unsafe.StringData(a) {
+		t.Error("Intern of an equal value must return the first stored instance")
+	}
+	if i.Len() != 1 || !i.Contains(b) {
+		t.Errorf("Len() = %d, want 1", i.Len())
+	}
+}
+
+func TestInterner_Set(t *testing.T) {
+	i := EmptyInterner[int]()
+	for _, v := range []int{1, 2, 2, 3} {
+		i.Intern(v)
+	}
+	if s := i.Set(); !s.Equal(Of(1, 2, 3)) {
+		t.Errorf("Set() = %v, want {1, 2, 3}", s)
+	}
+	if i.Contains(4) {
+		t.Error("Contains(4) = true, want false")
+	}
+}
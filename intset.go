@@ -0,0 +1,174 @@
+package set
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// IntSet is a data structure that contains a set of non-negative integers. It
+// is implemented using a bitset, which makes it considerably faster and
+// smaller than Set[int] for dense ranges of small integers. Its memory usage
+// is proportional to the largest element. The zero value is an empty set that
+// is ready to use.
+type IntSet struct {
+	// words is the bitset. Bit i%64 of words[i/64] is set if i is an
+	// element of the set.
+	words []uint64
+}
+
+// EmptyIntSet initializes a new IntSet without any elements inside it.
+func EmptyIntSet() *IntSet {
+	return &IntSet{}
+}
+
+// IntSetOf initializes a new IntSet and appends the given values to it.
+func IntSetOf(values ...int) *IntSet {
+	s := EmptyIntSet()
+	s.Add(values...)
+	return s
+}
+
+// Len returns the number of elements that s contains.
+func (s *IntSet) Len() int {
+	n := 0
+	for _, w := range s.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Contains reports whether s contains val.
+func (s *IntSet) Contains(val int) bool {
+	if val < 0 {
+		return false
+	}
+	i := val / 64
+	return i < len(s.words) && s.words[i]&(1<<(val%64)) != 0
+}
+
+// Add adds the values to s. It panics if any value is negative.
+func (s *IntSet) Add(values ...int) {
+	for _, v := range values {
+		if v < 0 {
+			panic("set: negative value added to IntSet")
+		}
+		i := v / 64
+		if i >= len(s.words) {
+			s.words = append(s.words, make([]uint64, i+1-len(s.words))...)
+		}
+		s.words[i] |= 1 << (v % 64)
+	}
+}
+
+// Delete removes the elements of values from s.
+func (s *IntSet) Delete(values ...int) {
+	for _, v := range values {
+		if v < 0 {
+			continue
+		}
+		if i := v / 64; i < len(s.words) {
+			s.words[i] &^= 1 << (v % 64)
+		}
+	}
+}
+
+// Clear removes all elements from s.
+func (s *IntSet) Clear() {
+	s.words = nil
+}
+
+// Slice converts s into a slice whose elements are in ascending order.
+func (s *IntSet) Slice() []int {
+	values := make([]int, 0, s.Len())
+	for v := range s.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// All returns an iterator over the elements of s in ascending order.
+func (s *IntSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range s.words {
+			for w != 0 {
+				b := bits.TrailingZeros64(w)
+				if !yield(i*64 + b) {
+					return
+				}
+				w &^= 1 << b
+			}
+		}
+	}
+}
+
+// Equal reports whether s and other contain exactly the same elements.
+func (s *IntSet) Equal(other *IntSet) bool {
+	a, b := s.words, other.words
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	for i, w := range a {
+		if i < len(b) {
+			if w != b[i] {
+				return false
+			}
+		} else if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new IntSet that contains every element that is present in s
+// or other.
+func (s *IntSet) Union(other *IntSet) *IntSet {
+	a, b := s.words, other.words
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	words := append([]uint64(nil), a...)
+	for i, w := range b {
+		words[i] |= w
+	}
+	return &IntSet{words: words}
+}
+
+// Intersection returns a new IntSet that contains only the elements that are
+// present in both s and other.
+func (s *IntSet) Intersection(other *IntSet) *IntSet {
+	n := len(s.words)
+	if len(other.words) < n {
+		n = len(other.words)
+	}
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = s.words[i] & other.words[i]
+	}
+	return &IntSet{words: words}
+}
+
+// Difference returns a new IntSet that contains the elements of s that are
+// not present in other.
+func (s *IntSet) Difference(other *IntSet) *IntSet {
+	words := append([]uint64(nil), s.words...)
+	for i := range words {
+		if i < len(other.words) {
+			words[i] &^= other.words[i]
+		}
+	}
+	return &IntSet{words: words}
+}
+
+// SymmetricDifference returns a new IntSet that contains the elements that
+// are present in exactly one of s and other.
+func (s *IntSet) SymmetricDifference(other *IntSet) *IntSet {
+	a, b := s.words, other.words
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	words := append([]uint64(nil), a...)
+	for i, w := range b {
+		words[i] ^= w
+	}
+	return &IntSet{words: words}
+}
@@ -0,0 +1,125 @@
+package set
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestIntSet(t *testing.T) {
+	var s IntSet
+	s.Add(0, 63, 64, 200, 63)
+	if s.Len() != 4 || !s.Contains(64) || s.Contains(65) || s.Contains(-1) || s.Contains(1000) {
+		t.Errorf("Slice() = %v, want [0 63 64 200]", s.Slice())
+	}
+	s.Delete(63, -5, 5000)
+	if got := s.Slice(); !slices.Equal(got, []int{0, 64, 200}) {
+		t.Errorf("Slice() = %v, want [0 64 200]", got)
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", s.Len())
+	}
+}
+
+func TestIntSet_AddNegativePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Add(-1) did not panic")
+		}
+	}()
+	EmptyIntSet().Add(-1)
+}
+
+func TestIntSet_EqualIgnoresTrailingWords(t *testing.T) {
+	a, b := IntSetOf(1), IntSetOf(1, 500)
+	b.Delete(500)
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Error("sets with trailing empty words must compare equal")
+	}
+}
+
+// toSet converts s into a Set[int] for cross-checking.
+func (s *IntSet) toSet() Set[int] {
+	return Collect(s.All())
+}
+
+func TestIntSet_MatchesSet(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomValues := func() []int {
+		values := make([]int, r.Intn(50))
+		for i := range values {
+			values[i] = r.Intn(1 + r.Intn(500))
+		}
+		return values
+	}
+	for range 200 {
+		av, bv := randomValues(), randomValues()
+		a, b := IntSetOf(av...), IntSetOf(bv...)
+		as, bs := FromSlice(av), FromSlice(bv)
+
+		del := randomValues()
+		a.Delete(del...)
+		as.Delete(del...)
+
+		checks := []struct {
+			name string
+			got  *IntSet
+			want Set[int]
+		}{
+			{"a", a, as},
+			{"Union", a.Union(b), as.Union(bs)},
+			{"Intersection", a.Intersection(b), as.Intersection(bs)},
+			{"Difference", a.Difference(b), as.Difference(bs)},
+			{"SymmetricDifference", a.SymmetricDifference(b), as.SymmetricDifference(bs)},
+		}
+		for _, c := range checks {
+			if !c.got.toSet().Equal(c.want) || c.got.Len() != c.want.Len() {
+				t.Fatalf("%s = %v, want %v", c.name, c.got.Slice(), Sorted(c.want))
+			}
+			if got := c.got.Slice(); !slices.Equal(got, Sorted(c.want)) {
+				t.Fatalf("%s.Slice() = %v, want %v", c.name, got, Sorted(c.want))
+			}
+		}
+		if a.Equal(b) != as.Equal(bs) {
+			t.Fatalf("Equal(%v, %v) disagrees with Set", a.Slice(), b.Slice())
+		}
+		for v := -1; v < 510; v++ {
+			if a.Contains(v) != as.Contains(v) {
+				t.Fatalf("Contains(%d) disagrees with Set", v)
+			}
+		}
+	}
+}
+
+func BenchmarkIntSet_Union(b *testing.B) {
+	x, y := EmptyIntSet(), EmptyIntSet()
+	for i := range 10000 {
+		if i%2 == 0 {
+			x.Add(i)
+		}
+		if i%3 == 0 {
+			y.Add(i)
+		}
+	}
+	b.ReportAllocs()
+	for range b.N {
+		x.Union(y)
+	}
+}
+
+func BenchmarkSet_UnionInts(b *testing.B) {
+	x, y := Empty[int](), Empty[int]()
+	for i := range 10000 {
+		if i%2 == 0 {
+			x.Append(i)
+		}
+		if i%3 == 0 {
+			y.Append(i)
+		}
+	}
+	b.ReportAllocs()
+	for range b.N {
+		x.Union(y)
+	}
+}
@@ -0,0 +1,45 @@
+package set
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// LineOptions controls how FromLinesWith turns lines into elements.
+type LineOptions struct {
+	// TrimSpace removes leading and trailing white space from every line.
+	TrimSpace bool
+
+	// SkipEmpty ignores lines that are empty, after trimming if TrimSpace is
+	// set.
+	SkipEmpty bool
+}
+
+// FromLines reads r line by line and returns a Set of the lines. Leading and
+// trailing white space is trimmed and empty lines are skipped, which suits
+// word lists and allowlists. Use FromLinesWith for more control.
+func FromLines(r io.Reader) (Set[string], error) {
+	return FromLinesWith(r, LineOptions{TrimSpace: true, SkipEmpty: true})
+}
+
+// FromLinesWith reads r line by line and returns a Set of the lines, processed
+// according to opts. Line endings are not part of the lines.
+func FromLinesWith(r io.Reader, opts LineOptions) (Set[string], error) {
+	s := Empty[string]()
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if opts.TrimSpace {
+			line = strings.TrimSpace(line)
+		}
+		if opts.SkipEmpty && line == "" {
+			continue
+		}
+		s.Append(line)
+	}
+	if err := sc.Err(); err != nil {
+		return Set[string]{}, err
+	}
+	return s, nil
+}
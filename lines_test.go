@@ -0,0 +1,47 @@
+package set
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestFromLines(t *testing.T) {
+	in := "alice\n  bob \r\n\n\ncarol\nalice"
+	s, err := FromLines(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(Of("alice", "bob", "carol")) {
+		t.Errorf("FromLines() = %v, want {alice, bob, carol}", s)
+	}
+}
+
+func TestFromLinesWith(t *testing.T) {
+	in := " a\n\nb \n"
+	tests := []struct {
+		opts LineOptions
+		want Set[string]
+	}{
+		{LineOptions{}, Of(" a", "", "b ")},
+		{LineOptions{TrimSpace: true}, Of("a", "", "b")},
+		{LineOptions{SkipEmpty: true}, Of(" a", "b ")},
+	}
+	for _, tt := range tests {
+		s, err := FromLinesWith(strings.NewReader(in), tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.Equal(tt.want) {
+			t.Errorf("FromLinesWith(%+v) = %v, want %v", tt.opts, s, tt.want)
+		}
+	}
+}
+
+func TestFromLinesError(t *testing.T) {
+	errRead := errors.New("read failed")
+	if _, err := FromLines(iotest.ErrReader(errRead)); !errors.Is(err, errRead) {
+		t.Errorf("FromLines() error = %v, want %v", err, errRead)
+	}
+}
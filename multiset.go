@@ -0,0 +1,79 @@
+package set
+
+// Multiset is a data structure that contains comparable elements together with
+// the number of times each of them was added. It is implemented using an
+// internal map.
+type Multiset[T comparable] struct {
+	// m is the internal map. Its keys are the elements of the multiset and
+	// the values are their positive multiplicities.
+	m map[T]int
+
+	// n is the sum of all multiplicities.
+	n int
+}
+
+// EmptyMultiset initializes a new Multiset without any elements inside it.
+func EmptyMultiset[T comparable]() *Multiset[T] {
+	return &Multiset[T]{m: make(map[T]int)}
+}
+
+// MultisetOf initializes a new Multiset and adds the given values to it.
+func MultisetOf[T comparable](values ...T) *Multiset[T] {
+	s := EmptyMultiset[T]()
+	s.Add(values...)
+	return s
+}
+
+// Add increments the multiplicity of each value by one.
+func (s *Multiset[T]) Add(values ...T) {
+	for _, v := range values {
+		s.m[v]++
+	}
+	s.n += len(values)
+}
+
+// Remove decrements the multiplicity of val by one and reports whether val was
+// present. Once its multiplicity drops to zero, val is removed.
+func (s *Multiset[T]) Remove(val T) bool {
+	c, ok := s.m[val]
+	if !ok {
+		return false
+	}
+	if c == 1 {
+		delete(s.m, val)
+	} else {
+		s.m[val] = c - 1
+	}
+	s.n--
+	return true
+}
+
+// Count returns the multiplicity of val, which is zero if val is not present.
+func (s *Multiset[T]) Count(val T) int {
+	return s.m[val]
+}
+
+// Contains reports whether s contains val at least once.
+func (s *Multiset[T]) Contains(val T) bool {
+	return s.m[val] > 0
+}
+
+// Len returns the number of elements that s contains, counting every element
+// as often as it was added.
+func (s *Multiset[T]) Len() int {
+	return s.n
+}
+
+// Distinct returns the number of distinct elements that s contains.
+func (s *Multiset[T]) Distinct() int {
+	return len(s.m)
+}
+
+// Set returns a new Set that contains the distinct elements of s.
+func (s *Multiset[T]) Set() Set[T] {
+	set := WithCapacity[T](len(s.m))
+	for v := range s.m {
+		set.Append(v)
+	}
+	return set
+}
@@ -0,0 +1,35 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestMultiset(t *testing.T) {
+	s := MultisetOf("a", "b", "a")
+	if s.Count("a") != 2 || s.Count("b") != 1 || s.Count("c") != 0 {
+		t.Errorf("Count() = %d, %d, %d, want 2, 1, 0", s.Count("a"), s.Count("b"), s.Count("c"))
+	}
+	if s.Len() != 3 || s.Distinct() != 2 {
+		t.Errorf("Len(), Distinct() = %d, %d, want 3, 2", s.Len(), s.Distinct())
+	}
+
+	if !s.Remove("a") || s.Count("a") != 1 || !s.Contains("a") {
+		t.Error("Remove() must decrement the multiplicity")
+	}
+	if !s.Remove("a") || s.Contains("a") || s.Distinct() != 1 {
+		t.Error("Remove() must drop an element whose multiplicity reaches zero")
+	}
+	if s.Remove("a") {
+		t.Error("Remove() of an absent element = true, want false")
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", s.Len())
+	}
+}
+
+func TestMultiset_Set(t *testing.T) {
+	s := MultisetOf(1, 1, 2)
+	if got := s.Set(); !got.Equal(Of(1, 2)) {
+		t.Errorf("Set() = %v, want {1, 2}", got)
+	}
+}
@@ -0,0 +1,115 @@
+package set
+
+import "iter"
+
+// ObservedSet is a Set that notifies registered callbacks about every
+// element that is added to or removed from it. It is always used through a
+// pointer, so every copy of the pointer shares both the elements and the
+// callbacks.
+type ObservedSet[T comparable] struct {
+	// s is the observed set.
+	s Set[T]
+
+	// onAdd and onRemove are the callbacks registered via OnAdd and
+	// OnRemove.
+	onAdd, onRemove []func(T)
+}
+
+// EmptyObserved initializes a new ObservedSet without any elements or
+// callbacks.
+func EmptyObserved[T comparable]() *ObservedSet[T] {
+	return &ObservedSet[T]{s: Empty[T]()}
+}
+
+// ObservedOf initializes a new ObservedSet that contains the given values.
+// No callbacks are registered yet, so the initial values are not reported.
+func ObservedOf[T comparable](values ...T) *ObservedSet[T] {
+	return &ObservedSet[T]{s: Of(values...)}
+}
+
+// OnAdd registers fn to be called with every element that is newly added to
+// s. Elements that are already present do not trigger fn.
+func (s *ObservedSet[T]) OnAdd(fn func(T)) {
+	s.onAdd = append(s.onAdd, fn)
+}
+
+// OnRemove registers fn to be called with every element that is removed from
+// s, including by Clear. Elements that are not present do not trigger fn.
+func (s *ObservedSet[T]) OnRemove(fn func(T)) {
+	s.onRemove = append(s.onRemove, fn)
+}
+
+// Len returns the number of elements that s contains.
+func (s *ObservedSet[T]) Len() int {
+	return s.s.Len()
+}
+
+// Contains reports whether s contains val.
+func (s *ObservedSet[T]) Contains(val T) bool {
+	return s.s.Contains(val)
+}
+
+// Append adds the values to s. If any value is already present, the value does
+// not impact the set.
+func (s *ObservedSet[T]) Append(values ...T) {
+	for _, v := range values {
+		s.Add(v)
+	}
+}
+
+// Add adds val to s and reports whether val was not already present.
+func (s *ObservedSet[T]) Add(val T) bool {
+	if !s.s.Add(val) {
+		return false
+	}
+	for _, fn := range s.onAdd {
+		fn(val)
+	}
+	return true
+}
+
+// Delete removes the elements of values from s.
+func (s *ObservedSet[T]) Delete(values ...T) {
+	for _, v := range values {
+		s.Remove(v)
+	}
+}
+
+// Remove removes val from s and reports whether val was present.
+func (s *ObservedSet[T]) Remove(val T) bool {
+	if !s.s.Remove(val) {
+		return false
+	}
+	for _, fn := range s.onRemove {
+		fn(val)
+	}
+	return true
+}
+
+// Clear removes all elements from s.
+func (s *ObservedSet[T]) Clear() {
+	old := s.s
+	s.s = Empty[T]()
+	for _, fn := range s.onRemove {
+		for v := range old.m {
+			fn(v)
+		}
+	}
+}
+
+// Slice converts s into a slice.
+func (s *ObservedSet[T]) Slice() []T {
+	return s.s.Slice()
+}
+
+// All returns an iterator over the elements of s. The iteration order is
+// unspecified.
+func (s *ObservedSet[T]) All() iter.Seq[T] {
+	return s.s.All()
+}
+
+// Set returns a new Set that contains the elements of s. Mutating the
+// returned set does not trigger the callbacks of s.
+func (s *ObservedSet[T]) Set() Set[T] {
+	return s.s.Clone()
+}
@@ -0,0 +1,74 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestObservedSet_Callbacks(t *testing.T) {
+	var added, removed []int
+	s := ObservedOf(1)
+	s.OnAdd(func(v int) { added = append(added, v) })
+	s.OnRemove(func(v int) { removed = append(removed, v) })
+
+	s.Append(1, 2, 3)
+	if !s.Add(4) || s.Add(4) {
+		t.Error("Add must report whether the value was newly added")
+	}
+	s.Delete(2, 5)
+	if !s.Remove(3) || s.Remove(3) {
+		t.Error("Remove must report whether the value was present")
+	}
+
+	if !slices.Equal(added, []int{2, 3, 4}) {
+		t.Errorf("added = %v, want [2 3 4]", added)
+	}
+	if !slices.Equal(removed, []int{2, 3}) {
+		t.Errorf("removed = %v, want [2 3]", removed)
+	}
+	if !s.Set().Equal(Of(1, 4)) {
+		t.Errorf("Set() = %v, want {1, 4}", s.Set())
+	}
+}
+
+func TestObservedSet_Clear(t *testing.T) {
+	var removed []int
+	s := ObservedOf(1, 2, 3)
+	s.OnRemove(func(v int) { removed = append(removed, v) })
+	s.Clear()
+
+	slices.Sort(removed)
+	if !slices.Equal(removed, []int{1, 2, 3}) {
+		t.Errorf("removed = %v, want [1 2 3]", removed)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", s.Len())
+	}
+}
+
+func TestObservedSet_SharedCopies(t *testing.T) {
+	var added []int
+	s := EmptyObserved[int]()
+	c := s
+	s.OnAdd(func(v int) { added = append(added, v) })
+	c.Append(7)
+
+	if !slices.Equal(added, []int{7}) {
+		t.Errorf("added = %v, want [7]", added)
+	}
+	if !s.Contains(7) {
+		t.Error("Contains(7) = false, want true")
+	}
+}
+
+func TestObservedSet_SetIsDetached(t *testing.T) {
+	var added []int
+	s := ObservedOf(1)
+	s.OnAdd(func(v int) { added = append(added, v) })
+	c := s.Set()
+	c.Append(2)
+
+	if len(added) != 0 || s.Contains(2) {
+		t.Error("mutating the result of Set must not affect s")
+	}
+}
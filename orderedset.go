@@ -0,0 +1,108 @@
+package set
+
+import "iter"
+
+// OrderedSet is a data structure that contains a set of comparable elements
+// and remembers the order in which they were first inserted. Iteration and
+// Slice return the elements in insertion order.
+type OrderedSet[T comparable] struct {
+	// index maps every element of the set to its position in order.
+	index map[T]int
+
+	// order contains the elements in insertion order. Deleted elements leave
+	// a stale entry behind, which is recognized by index not pointing to its
+	// position. Stale entries are compacted once they outnumber the live
+	// ones.
+	order []T
+}
+
+// EmptyOrdered initializes a new OrderedSet without any elements inside it.
+func EmptyOrdered[T comparable]() *OrderedSet[T] {
+	return &OrderedSet[T]{index: make(map[T]int)}
+}
+
+// OrderedOf initializes a new OrderedSet and appends the given values to it in
+// order.
+func OrderedOf[T comparable](values ...T) *OrderedSet[T] {
+	s := EmptyOrdered[T]()
+	s.Append(values...)
+	return s
+}
+
+// Len returns the number of elements that s contains.
+func (s *OrderedSet[T]) Len() int {
+	return len(s.index)
+}
+
+// Contains reports whether s contains val.
+func (s *OrderedSet[T]) Contains(val T) bool {
+	_, ok := s.index[val]
+	return ok
+}
+
+// Append adds the values to the end of s. If any value is already present,
+// the value does not impact the set and keeps its original position.
+func (s *OrderedSet[T]) Append(values ...T) {
+	for _, v := range values {
+		if _, ok := s.index[v]; ok {
+			continue
+		}
+		s.index[v] = len(s.order)
+		s.order = append(s.order, v)
+	}
+}
+
+// Delete removes the elements of values from s.
+func (s *OrderedSet[T]) Delete(values ...T) {
+	for _, v := range values {
+		delete(s.index, v)
+	}
+	if len(s.order) > 2*len(s.index) {
+		s.compact()
+	}
+}
+
+// Clear removes all elements from s.
+func (s *OrderedSet[T]) Clear() {
+	s.index = make(map[T]int)
+	s.order = nil
+}
+
+// Slice converts s into a slice whose elements are in insertion order.
+func (s *OrderedSet[T]) Slice() []T {
+	values := make([]T, 0, len(s.index))
+	for v := range s.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// All returns an iterator over the elements of s in insertion order.
+func (s *OrderedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i, v := range s.order {
+			if s.live(i) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// live reports whether the entry of order at position i is still part of the
+// set.
+func (s *OrderedSet[T]) live(i int) bool {
+	j, ok := s.index[s.order[i]]
+	return ok && i == j
+}
+
+// compact removes the stale entries from order.
+func (s *OrderedSet[T]) compact() {
+	order := make([]T, 0, len(s.index))
+	for i, v := range s.order {
+		if s.live(i) {
+			s.index[v] = len(order)
+			order = append(order, v)
+		}
+	}
+	s.order = order
+}
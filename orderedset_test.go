@@ -0,0 +1,56 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOrderedSet_InsertionOrder(t *testing.T) {
+	s := OrderedOf(3, 1, 2, 1)
+	if got := s.Slice(); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("Slice() = %v, want [3 1 2]", got)
+	}
+	s.Delete(1)
+	s.Append(4, 1)
+	if got := s.Slice(); !slices.Equal(got, []int{3, 2, 4, 1}) {
+		t.Errorf("Slice() after re-adding = %v, want [3 2 4 1]", got)
+	}
+	if s.Len() != 4 || !s.Contains(1) || s.Contains(5) {
+		t.Errorf("Len() = %d, want 4", s.Len())
+	}
+}
+
+func TestOrderedSet_Compaction(t *testing.T) {
+	s := EmptyOrdered[int]()
+	for i := range 100 {
+		s.Append(i)
+	}
+	for i := range 90 {
+		s.Delete(i)
+	}
+	if len(s.order) > 2*s.Len() {
+		t.Errorf("len(order) = %d, want at most %d", len(s.order), 2*s.Len())
+	}
+	want := []int{90, 91, 92, 93, 94, 95, 96, 97, 98, 99}
+	if got := s.Slice(); !slices.Equal(got, want) {
+		t.Errorf("Slice() = %v, want %v", got, want)
+	}
+}
+
+func TestOrderedSet_All(t *testing.T) {
+	s := OrderedOf("a", "b", "c")
+	var got []string
+	for v := range s.All() {
+		got = append(got, v)
+		if v == "b" {
+			break
+		}
+	}
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("All() yielded %v, want [a b]", got)
+	}
+	s.Clear()
+	if s.Len() != 0 || len(s.Slice()) != 0 {
+		t.Errorf("Clear() left %v", s.Slice())
+	}
+}
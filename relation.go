@@ -0,0 +1,62 @@
+package set
+
+// Relation describes how two sets relate to each other.
+type Relation int
+
+const (
+	// RelationEqual means that both sets contain exactly the same elements.
+	RelationEqual Relation = iota
+
+	// RelationSubset means that the first set is a proper subset of the
+	// second one. This includes the empty set compared to a non-empty set.
+	RelationSubset
+
+	// RelationSuperset means that the first set is a proper superset of the
+	// second one. This includes a non-empty set compared to the empty set.
+	RelationSuperset
+
+	// RelationDisjoint means that both sets are non-empty and have no
+	// elements in common.
+	RelationDisjoint
+
+	// RelationOverlap means that both sets have some, but not all, elements
+	// in common.
+	RelationOverlap
+)
+
+// String returns the name of r.
+func (r Relation) String() string {
+	switch r {
+	case RelationEqual:
+		return "equal"
+	case RelationSubset:
+		return "subset"
+	case RelationSuperset:
+		return "superset"
+	case RelationDisjoint:
+		return "disjoint"
+	case RelationOverlap:
+		return "overlap"
+	default:
+		return "unknown"
+	}
+}
+
+// Relation reports how s relates to other. It determines the relation by
+// counting their common elements once, which is cheaper than calling Equal,
+// IsSubset, IsSuperset and IsDisjoint individually.
+func (s Set[T]) Relation(other Set[T]) Relation {
+	n := s.intersectionLen(other)
+	switch {
+	case n == s.Len() && n == other.Len():
+		return RelationEqual
+	case n == s.Len():
+		return RelationSubset
+	case n == other.Len():
+		return RelationSuperset
+	case n == 0:
+		return RelationDisjoint
+	default:
+		return RelationOverlap
+	}
+}
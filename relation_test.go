@@ -0,0 +1,42 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestSet_Relation(t *testing.T) {
+	tests := []struct {
+		a, b Set[int]
+		want Relation
+	}{
+		{Of(1, 2), Of(2, 1), RelationEqual},
+		{Empty[int](), Empty[int](), RelationEqual},
+		{Of(1), Of(1, 2), RelationSubset},
+		{Empty[int](), Of(1), RelationSubset},
+		{Of(1, 2), Of(1), RelationSuperset},
+		{Of(1), Empty[int](), RelationSuperset},
+		{Of(1), Of(2), RelationDisjoint},
+		{Of(1, 2), Of(2, 3), RelationOverlap},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Relation(tt.b); got != tt.want {
+			t.Errorf("%v.Relation(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRelation_String(t *testing.T) {
+	names := map[Relation]string{
+		RelationEqual:    "equal",
+		RelationSubset:   "subset",
+		RelationSuperset: "superset",
+		RelationDisjoint: "disjoint",
+		RelationOverlap:  "overlap",
+		Relation(-1):     "unknown",
+	}
+	for r, want := range names {
+		if got := r.String(); got != want {
+			t.Errorf("Relation(%d).String() = %q, want %q", int(r), got, want)
+		}
+	}
+}
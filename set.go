@@ -1,84 +1,1381 @@
 package set
 
-import "encoding/json"
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
 
 // Set is a data structure that contains a set of comparable elements. It is
-// implemented using an internal map.
+// implemented using an internal map. The zero value is an empty set that is
+// ready to use.
+//
+// Methods that may have to allocate the internal map, such as Append and Add,
+// take a pointer receiver, so they cannot be called on values that are not
+// addressable, like the result of Of or a map entry. All other methods take a
+// value receiver.
+//
+// Copying a Set copies a reference to its internal map. Copies of a set that
+// has been allocated therefore share their elements, whereas copies of a zero
+// value Set allocate separate maps when they are first mutated and diverge.
+// Clear, Shrink and Grow, as well as the decoding methods such as
+// UnmarshalJSON, replace the internal map of the set they are called on, which
+// detaches it from its copies. Use Clone to obtain an independent copy.
 type Set[T comparable] struct {
-	// m is the internal map. Its keys are the elements of the set and the
-	// values are booleans that indicates whether the key is present or not.
-	m map[T]bool
+	// m is the internal map. Its keys are the elements of the set. It is nil
+	// until the first element is added to a zero value Set.
+	m map[T]struct{}
 }
 
 var (
+	_ fmt.Stringer     = (*Set[int])(nil)
 	_ json.Marshaler   = (*Set[int])(nil)
 	_ json.Unmarshaler = (*Set[int])(nil)
 )
 
 // Empty initializes a new Set without any elements inside it.
 func Empty[T comparable]() Set[T] {
-	return Set[T]{m: make(map[T]bool)}
+	return Set[T]{m: make(map[T]struct{})}
+}
+
+// WithCapacity initializes a new Set without any elements inside it. The
+// internal map is allocated with enough space to hold n elements.
+func WithCapacity[T comparable](n int) Set[T] {
+	return Set[T]{m: make(map[T]struct{}, n)}
 }
 
 // Of initializes a new Set and appends the given values to it.
 func Of[T comparable](values ...T) Set[T] {
-	s := Empty[T]()
+	s := WithCapacity[T](len(values))
 	s.Append(values...)
 	return s
 }
 
+// FromSlice initializes a new Set and appends the elements of values to it.
+func FromSlice[T comparable](values []T) Set[T] {
+	return Of(values...)
+}
+
+// FromKeys initializes a new Set that contains the keys of m.
+func FromKeys[T comparable, V any](m map[T]V) Set[T] {
+	s := WithCapacity[T](len(m))
+	for k := range m {
+		s.m[k] = struct{}{}
+	}
+	return s
+}
+
+// Collect initializes a new Set and appends the values yielded by seq to it.
+func Collect[T comparable](seq iter.Seq[T]) Set[T] {
+	s := Empty[T]()
+	s.AppendSeq(seq)
+	return s
+}
+
 // Len returns the number of elements that s contains.
 func (s Set[T]) Len() int {
 	return len(s.m)
 }
 
+// IsEmpty reports whether s contains no elements.
+func (s Set[T]) IsEmpty() bool {
+	return len(s.m) == 0
+}
+
 // Contains reports whether s contains val.
 func (s Set[T]) Contains(val T) bool {
-	return s.m[val]
+	_, ok := s.m[val]
+	return ok
+}
+
+// ContainsAll reports whether s contains every value of values. It returns
+// true if values is empty.
+func (s Set[T]) ContainsAll(values ...T) bool {
+	for _, v := range values {
+		if !s.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny reports whether s contains at least one value of values. It
+// returns false if values is empty.
+func (s Set[T]) ContainsAny(values ...T) bool {
+	for _, v := range values {
+		if s.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// CountPresent returns how many of values are present in s. Every occurrence
+// of a value in values is counted, including duplicates.
+func (s Set[T]) CountPresent(values ...T) int {
+	n := 0
+	for _, v := range values {
+		if s.Contains(v) {
+			n++
+		}
+	}
+	return n
 }
 
 // Append adds the values to s. If any value is already present, the value does
 // not impact the set.
-func (s Set[T]) Append(values ...T) {
+func (s *Set[T]) Append(values ...T) {
+	s.lazyInit()
+	for _, v := range values {
+		s.insert(v)
+	}
+}
+
+// Add adds val to s and reports whether val was not already present.
+func (s *Set[T]) Add(val T) bool {
+	s.lazyInit()
+	return s.insert(val)
+}
+
+// AddAll adds the values to s and returns the number of values that were not
+// already present. A value that occurs multiple times in values is counted
+// at most once.
+func (s *Set[T]) AddAll(values ...T) int {
+	s.lazyInit()
+	n := 0
+	for _, v := range values {
+		if s.insert(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// AppendSeq adds the values yielded by seq to s.
+func (s *Set[T]) AppendSeq(seq iter.Seq[T]) {
+	s.lazyInit()
+	for v := range seq {
+		s.insert(v)
+	}
+}
+
+// AppendSlice adds the elements of values to s, sizing a new map to fit them.
+func (s *Set[T]) AppendSlice(values []T) {
+	if s.m == nil {
+		s.m = make(map[T]struct{}, len(values))
+	}
 	for _, v := range values {
-		s.m[v] = true
+		s.insert(v)
+	}
+}
+
+// With adds the values to s and returns s, which allows calls to be chained,
+// e.g. Empty[int]().With(1, 2).Without(2). The returned set shares its
+// internal map with s; only if s is the zero value, the values are added to
+// the returned set alone.
+func (s Set[T]) With(values ...T) Set[T] {
+	s.Append(values...)
+	return s
+}
+
+// Without removes the values from s and returns s, which allows calls to be
+// chained like With.
+func (s Set[T]) Without(values ...T) Set[T] {
+	s.Delete(values...)
+	return s
+}
+
+// lazyInit allocates the internal map of s if it has not been allocated yet.
+func (s *Set[T]) lazyInit() {
+	if s.m == nil {
+		s.m = make(map[T]struct{})
 	}
 }
 
+// rebuild replaces the internal map of s with a copy that is allocated with
+// enough space to hold size elements.
+func (s *Set[T]) rebuild(size int) {
+	m := make(map[T]struct{}, size)
+	for v := range s.m {
+		m[v] = struct{}{}
+	}
+	s.m = m
+}
+
+// insert adds val to the allocated internal map of s and reports whether val
+// was not already present.
+func (s *Set[T]) insert(val T) bool {
+	if _, ok := s.m[val]; ok {
+		return false
+	}
+	s.m[val] = struct{}{}
+	return true
+}
+
+// remove deletes val from s and reports whether val was present.
+func (s Set[T]) remove(val T) bool {
+	if _, ok := s.m[val]; !ok {
+		return false
+	}
+	delete(s.m, val)
+	return true
+}
+
 // Delete removes the elements of values from s.
 func (s Set[T]) Delete(values ...T) {
 	for _, v := range values {
-		delete(s.m, v)
+		s.remove(v)
+	}
+}
+
+// Remove removes val from s and reports whether val was present.
+func (s Set[T]) Remove(val T) bool {
+	return s.remove(val)
+}
+
+// Toggle removes val from s if it is present and adds it otherwise. It
+// reports whether val is present after the call.
+func (s *Set[T]) Toggle(val T) bool {
+	s.lazyInit()
+	if s.remove(val) {
+		return false
+	}
+	return s.insert(val)
+}
+
+// Pop removes an arbitrary element from s and returns it. The boolean reports
+// whether s contained any element; if s is empty, Pop returns the zero value
+// of T and false.
+func (s Set[T]) Pop() (T, bool) {
+	for v := range s.m {
+		s.remove(v)
+		return v, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Sample returns up to n distinct elements of s chosen at random. If n is
+// greater than or equal to s.Len(), all elements of s are returned in random
+// order.
+func (s Set[T]) Sample(n int) []T {
+	return s.SampleWith(nil, n)
+}
+
+// SampleWith is like Sample but draws the random numbers from r. If r is nil,
+// the default source of the math/rand package is used.
+func (s Set[T]) SampleWith(r *rand.Rand, n int) []T {
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+	values := s.Slice()
+	if n > len(values) {
+		n = len(values)
+	}
+	if n < 0 {
+		n = 0
+	}
+	for i := 0; i < n; i++ {
+		j := i + intn(len(values)-i)
+		values[i], values[j] = values[j], values[i]
+	}
+	return values[:n]
+}
+
+// SampleWeighted returns up to n distinct elements of s chosen at random,
+// where the probability of choosing an element is proportional to weight(elem).
+// Elements with a zero or negative weight are never chosen, so fewer than n
+// elements are returned if not enough elements have a positive weight. The
+// random numbers are drawn from r; if r is nil, the default source of the
+// math/rand package is used.
+func (s Set[T]) SampleWeighted(weight func(T) float64, n int, r *rand.Rand) []T {
+	exp := rand.ExpFloat64
+	if r != nil {
+		exp = r.ExpFloat64
+	}
+	// Every candidate is assigned an exponentially distributed key with a
+	// rate equal to its weight. Picking the n smallest keys is equivalent to
+	// drawing n elements one after another without replacement.
+	type candidate struct {
+		val T
+		key float64
+	}
+	candidates := make([]candidate, 0, len(s.m))
+	for v := range s.m {
+		if w := weight(v); w > 0 {
+			candidates = append(candidates, candidate{val: v, key: exp() / w})
+		}
 	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	n = max(0, min(n, len(candidates)))
+	values := make([]T, n)
+	for i := range values {
+		values[i] = candidates[i].val
+	}
+	return values
 }
 
 // Clear removes all elements from s.
-func (s Set[T]) Clear() {
-	s.m = make(map[T]bool)
+func (s *Set[T]) Clear() {
+	s.m = make(map[T]struct{})
+}
+
+// ClearInPlace removes all elements from s but, unlike Clear, keeps the
+// storage of the internal map allocated. This avoids allocations when a set is
+// cleared and refilled repeatedly; use Clear to reclaim the memory instead.
+func (s Set[T]) ClearInPlace() {
+	clear(s.m)
+}
+
+// Shrink rebuilds the internal map of s with a size matching s.Len(). Go maps
+// do not release their storage when elements are deleted, so Shrink can be
+// used to reclaim memory after a large set has been mostly drained. The
+// iteration order of s may differ afterwards.
+func (s *Set[T]) Shrink() {
+	s.rebuild(len(s.m))
+}
+
+// Grow makes room for at least n more elements in s, so that inserting them
+// does not reallocate the internal map. Go maps do not report their capacity,
+// so Grow always rebuilds the internal map if n is positive; call it once
+// before a bulk insertion rather than repeatedly. Like Shrink and Clear, it
+// replaces the internal map, so copies of s no longer share its elements
+// afterwards. It panics if n is negative.
+func (s *Set[T]) Grow(n int) {
+	if n < 0 {
+		panic("set: negative Grow count")
+	}
+	if n > 0 {
+		s.rebuild(len(s.m) + n)
+	}
 }
 
 // Slice converts s into a slice.
 func (s Set[T]) Slice() []T {
-	values := make([]T, len(s.m))
+	return s.SliceInto(make([]T, 0, len(s.m)))
+}
+
+// SliceInto appends the elements of s to dst and returns the extended slice.
+// The existing content of dst is preserved. If dst has enough spare capacity,
+// SliceInto does not allocate, which allows buffers to be reused.
+func (s Set[T]) SliceInto(dst []T) []T {
 	for t := range s.m {
-		values = append(values, t)
+		dst = append(dst, t)
+	}
+	return dst
+}
+
+// Values returns an iterator over the elements of s. It is equivalent to All
+// and pairs naturally with Collect to transform a set with iterator adapters.
+func (s Set[T]) Values() iter.Seq[T] {
+	return s.All()
+}
+
+// ForEach calls fn for each element of s until fn returns false. The
+// iteration order is unspecified.
+func (s Set[T]) ForEach(fn func(T) bool) {
+	for v := range s.m {
+		if !fn(v) {
+			return
+		}
+	}
+}
+
+// Any reports whether pred returns true for at least one element of s. It
+// returns false if s is empty.
+func (s Set[T]) Any(pred func(T) bool) bool {
+	for v := range s.m {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every reports whether pred returns true for every element of s. It returns
+// true if s is empty.
+func (s Set[T]) Every(pred func(T) bool) bool {
+	for v := range s.m {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find returns an element of s for which pred returns true. If multiple
+// elements satisfy pred, it is unspecified which one is returned. The boolean
+// reports whether such an element exists; if not, Find returns the zero value
+// of T and false.
+func (s Set[T]) Find(pred func(T) bool) (T, bool) {
+	for v := range s.m {
+		if pred(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Count returns the number of elements of s for which pred returns true.
+func (s Set[T]) Count(pred func(T) bool) int {
+	n := 0
+	for v := range s.m {
+		if pred(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// Clone returns a new Set that contains the same elements as s. The returned
+// set does not share its internal map with s, so mutations to either set do
+// not affect the other. The elements themselves are copied by assignment, so
+// elements that refer to shared data, e.g. pointers, keep referring to it;
+// use CloneFunc to copy such elements as well.
+func (s Set[T]) Clone() Set[T] {
+	c := Set[T]{m: make(map[T]struct{}, s.Len())}
+	for v := range s.m {
+		c.m[v] = struct{}{}
+	}
+	return c
+}
+
+// CloneFunc returns a new Set that contains the result of cloneElem for every
+// element of s. It generalizes Clone for elements that refer to shared data
+// and need to be copied deeply.
+func CloneFunc[T comparable](s Set[T], cloneElem func(T) T) Set[T] {
+	return Map(s, cloneElem)
+}
+
+// SliceSeeded converts s into a slice whose order is a pseudo-random
+// permutation determined by seed and hash. Unlike Slice, the order does not
+// depend on Go's randomized map iteration, so equal sets, seeds and hash
+// functions always produce the same order, which is useful for reproducible
+// fuzzing and sharding. Elements whose hashes collide are ordered
+// arbitrarily.
+func (s Set[T]) SliceSeeded(seed uint64, hash func(T) uint64) []T {
+	type keyed struct {
+		val T
+		key uint64
+	}
+	elems := make([]keyed, 0, len(s.m))
+	for v := range s.m {
+		elems = append(elems, keyed{val: v, key: mix64(hash(v) ^ mix64(seed))})
+	}
+	slices.SortFunc(elems, func(a, b keyed) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	values := make([]T, len(elems))
+	for i, e := range elems {
+		values[i] = e.val
 	}
 	return values
 }
 
+// All returns an iterator over the elements of s. The iteration order is
+// unspecified.
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new Set that contains every element that is present in s or
+// other. Neither s nor other is modified.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	n := s.Len()
+	if other.Len() > n {
+		n = other.Len()
+	}
+	u := Set[T]{m: make(map[T]struct{}, n)}
+	for v := range s.m {
+		u.m[v] = struct{}{}
+	}
+	for v := range other.m {
+		u.m[v] = struct{}{}
+	}
+	return u
+}
+
+// Intersection returns a new Set that contains only the elements that are
+// present in both s and other. Neither s nor other is modified.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	i := Empty[T]()
+	for v := range small.m {
+		if large.Contains(v) {
+			i.m[v] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Difference returns a new Set that contains the elements of s that are not
+// present in other. The operation is not symmetric: a.Difference(b) holds the
+// elements only in a, whereas b.Difference(a) holds the elements only in b.
+// Neither s nor other is modified.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	d := Empty[T]()
+	for v := range s.m {
+		if !other.Contains(v) {
+			d.m[v] = struct{}{}
+		}
+	}
+	return d
+}
+
+// SymmetricDifference returns a new Set that contains the elements that are
+// present in exactly one of s and other. Neither s nor other is modified.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	d := Empty[T]()
+	for v := range s.m {
+		if !other.Contains(v) {
+			d.m[v] = struct{}{}
+		}
+	}
+	for v := range other.m {
+		if !s.Contains(v) {
+			d.m[v] = struct{}{}
+		}
+	}
+	return d
+}
+
+// Complement returns a new Set that contains the elements of universe that
+// are not present in s. Elements of s that are not present in universe are
+// ignored. Neither s nor universe is modified.
+func (s Set[T]) Complement(universe Set[T]) Set[T] {
+	return universe.Difference(s)
+}
+
+// Diff compares s, as the old state, with other, as the new state. added
+// contains the elements of other that are not present in s and removed
+// contains the elements of s that are not present in other. Neither s nor
+// other is modified.
+func (s Set[T]) Diff(other Set[T]) (added, removed Set[T]) {
+	return other.Difference(s), s.Difference(other)
+}
+
+// DiffCounts is like Diff but only returns the number of added and removed
+// elements, without allocating any set.
+func (s Set[T]) DiffCounts(other Set[T]) (added, removed int) {
+	inter := s.intersectionLen(other)
+	return other.Len() - inter, s.Len() - inter
+}
+
+// IsSubset reports whether every element of s is present in other. The empty
+// set is a subset of every set.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for v := range s.m {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every element of other is present in s.
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s Set[T]) IsDisjoint(other Set[T]) bool {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	for v := range small.m {
+		if large.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAnyOf reports whether s contains at least one element of values. It
+// is like ContainsAny but takes the values as a slice.
+func (s Set[T]) ContainsAnyOf(values []T) bool {
+	return s.ContainsAny(values...)
+}
+
+// IntersectSlice returns a new Set that contains the elements of values that
+// are present in s. It is like Intersection but probes s with values
+// directly instead of requiring them to be converted into a Set first.
+func (s Set[T]) IntersectSlice(values []T) Set[T] {
+	i := Empty[T]()
+	for _, v := range values {
+		if s.Contains(v) {
+			i.m[v] = struct{}{}
+		}
+	}
+	return i
+}
+
+// UnionSeq returns a new Set that contains the elements of s and every value
+// yielded by seq. s is not modified. The Seq variants consume an iterator and
+// return a Set, whereas the Iter variants, such as UnionIter, combine two sets
+// into an iterator.
+func (s Set[T]) UnionSeq(seq iter.Seq[T]) Set[T] {
+	u := s.Clone()
+	u.AppendSeq(seq)
+	return u
+}
+
+// IntersectSeq returns a new Set that contains the elements of s that are
+// also yielded by seq. s is not modified.
+func (s Set[T]) IntersectSeq(seq iter.Seq[T]) Set[T] {
+	i := Empty[T]()
+	for v := range seq {
+		if s.Contains(v) {
+			i.m[v] = struct{}{}
+		}
+	}
+	return i
+}
+
+// DifferenceIter returns an iterator over the elements of s that are not
+// present in other. It is like Difference but does not allocate a set for the
+// result. The iteration order is unspecified. Unlike the Seq variants, such as
+// UnionSeq, the Iter variants take a Set and return an iterator.
+func (s Set[T]) DifferenceIter(other Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.m {
+			if !other.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// IntersectionIter returns an iterator over the elements that are present in
+// both s and other. It is like Intersection but does not allocate a set for
+// the result. The iteration order is unspecified.
+func (s Set[T]) IntersectionIter(other Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		small, large := s, other
+		if small.Len() > large.Len() {
+			small, large = large, small
+		}
+		for v := range small.m {
+			if large.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// UnionIter returns an iterator over the elements that are present in s or
+// other, yielding each element once. It is like Union but does not allocate a
+// set for the result. The iteration order is unspecified.
+func (s Set[T]) UnionIter(other Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.m {
+			if !yield(v) {
+				return
+			}
+		}
+		for v := range other.m {
+			if !s.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Equal reports whether s and other contain exactly the same elements.
+func (s Set[T]) Equal(other Set[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for v := range s.m {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge adds the elements of every set in others to s. Unlike Union, Merge
+// mutates s in place instead of allocating a new set. The sets in others are
+// left untouched.
+func (s *Set[T]) Merge(others ...Set[T]) {
+	s.lazyInit()
+	for _, o := range others {
+		for v := range o.m {
+			s.insert(v)
+		}
+	}
+}
+
+// RetainAll removes every element from s that is not present in other. Unlike
+// Intersection, RetainAll mutates s in place instead of allocating a new set.
+func (s Set[T]) RetainAll(other Set[T]) {
+	// Deleting the entry that is currently being visited is safe while
+	// ranging over a map.
+	for v := range s.m {
+		if !other.Contains(v) {
+			s.remove(v)
+		}
+	}
+}
+
+// RetainSlice removes every element from s that is not present in values. It
+// is like RetainAll but takes the elements to keep as a slice, so the caller
+// does not need to convert them into a Set first. Membership in a slice
+// cannot be tested in constant time, so the elements of values that are
+// present in s are collected into a temporary map first; it holds at most
+// min(len(values), s.Len()) elements. If values is empty, s is cleared without
+// allocating.
+func (s Set[T]) RetainSlice(values []T) {
+	if len(values) == 0 {
+		s.ClearInPlace()
+		return
+	}
+	keep := make(map[T]struct{}, min(len(values), len(s.m)))
+	for _, v := range values {
+		if s.Contains(v) {
+			keep[v] = struct{}{}
+		}
+	}
+	if len(keep) == len(s.m) {
+		return
+	}
+	for v := range s.m {
+		if _, ok := keep[v]; !ok {
+			s.remove(v)
+		}
+	}
+}
+
+// SymmetricDifferenceInPlace turns s into the symmetric difference of s and
+// other by removing the elements that are present in both and adding the
+// elements that are only present in other. Unlike SymmetricDifference, it
+// mutates s in place instead of allocating a new set. other is left
+// untouched.
+func (s *Set[T]) SymmetricDifferenceInPlace(other Set[T]) {
+	s.lazyInit()
+	// Every element of other is visited exactly once and its membership in
+	// s is decided before s is mutated for it, so elements that were
+	// removed are never added back. If other shares its map with s, all
+	// elements are removed and none is added.
+	for v := range other.m {
+		if !s.remove(v) {
+			s.insert(v)
+		}
+	}
+}
+
+// SubtractAll removes every element from s that is present in any of others.
+// Unlike Difference, SubtractAll mutates s in place instead of allocating a
+// new set. The sets in others are left untouched.
+func (s Set[T]) SubtractAll(others ...Set[T]) {
+	for _, o := range others {
+		if s.Len() == 0 {
+			return
+		}
+		if o.Len() < s.Len() {
+			for v := range o.m {
+				s.remove(v)
+			}
+			continue
+		}
+		for v := range s.m {
+			if o.Contains(v) {
+				s.remove(v)
+			}
+		}
+	}
+}
+
+// DeleteFunc removes every element from s for which pred returns true and
+// returns the number of removed elements.
+func (s Set[T]) DeleteFunc(pred func(T) bool) int {
+	n := 0
+	for v := range s.m {
+		if pred(v) {
+			s.remove(v)
+			n++
+		}
+	}
+	return n
+}
+
+// Filter returns a new Set that contains the elements of s for which pred
+// returns true. s is not modified.
+func (s Set[T]) Filter(pred func(T) bool) Set[T] {
+	f := Empty[T]()
+	for v := range s.m {
+		if pred(v) {
+			f.m[v] = struct{}{}
+		}
+	}
+	return f
+}
+
+// Jaccard returns the Jaccard similarity coefficient of s and other, which is
+// the number of elements in their intersection divided by the number of
+// elements in their union. Two empty sets have a coefficient of 1.
+func (s Set[T]) Jaccard(other Set[T]) float64 {
+	if s.Len() == 0 && other.Len() == 0 {
+		return 1
+	}
+	inter := s.intersectionLen(other)
+	return float64(inter) / float64(s.Len()+other.Len()-inter)
+}
+
+// Overlap returns the overlap coefficient of s and other, which is the number
+// of elements in their intersection divided by the number of elements of the
+// smaller set. Two empty sets have a coefficient of 1; if only one of them is
+// empty, the coefficient is 0.
+func (s Set[T]) Overlap(other Set[T]) float64 {
+	if s.Len() == 0 && other.Len() == 0 {
+		return 1
+	}
+	n := s.Len()
+	if other.Len() < n {
+		n = other.Len()
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(s.intersectionLen(other)) / float64(n)
+}
+
+// Dice returns the Sørensen-Dice coefficient of s and other, which is twice
+// the number of elements in their intersection divided by the sum of their
+// lengths. Two empty sets have a coefficient of 1; if only one of them is
+// empty, the coefficient is 0.
+func (s Set[T]) Dice(other Set[T]) float64 {
+	if s.Len() == 0 && other.Len() == 0 {
+		return 1
+	}
+	return 2 * float64(s.intersectionLen(other)) / float64(s.Len()+other.Len())
+}
+
+// intersectionLen returns the number of elements that are present in both s
+// and other without allocating their intersection.
+func (s Set[T]) intersectionLen(other Set[T]) int {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	n := 0
+	for v := range small.m {
+		if large.Contains(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// Partition splits s into the elements for which pred returns true and the
+// elements for which it returns false. The two resulting sets are disjoint and
+// their union equals s. s is not modified.
+func (s Set[T]) Partition(pred func(T) bool) (matched, unmatched Set[T]) {
+	matched, unmatched = Empty[T](), Empty[T]()
+	for v := range s.m {
+		if pred(v) {
+			matched.m[v] = struct{}{}
+		} else {
+			unmatched.m[v] = struct{}{}
+		}
+	}
+	return matched, unmatched
+}
+
+// Chunk splits s into sets of at most size elements each, e.g. to process the
+// elements in batches. Every element of s is part of exactly one chunk and
+// all chunks but the last one contain exactly size elements. It panics if
+// size is less than 1.
+func (s Set[T]) Chunk(size int) []Set[T] {
+	if size < 1 {
+		panic("set: chunk size must be positive")
+	}
+	chunks := make([]Set[T], 0, s.Len()/size+min(1, s.Len()%size))
+	var chunk Set[T]
+	for v := range s.m {
+		if chunk.Len() == 0 {
+			chunk = WithCapacity[T](min(size, s.Len()-len(chunks)*size))
+		}
+		chunk.m[v] = struct{}{}
+		if chunk.Len() == size {
+			chunks = append(chunks, chunk)
+			chunk = Set[T]{}
+		}
+	}
+	if chunk.Len() > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// Map applies f to every element of s and returns a new Set of the results.
+// Elements that f maps to the same value collapse into a single element, so
+// the result may contain fewer elements than s.
+func Map[T, U comparable](s Set[T], f func(T) U) Set[U] {
+	m := Set[U]{m: make(map[U]struct{}, s.Len())}
+	for v := range s.m {
+		m.m[f(v)] = struct{}{}
+	}
+	return m
+}
+
+// ContainsBy reports whether s contains key(val). It allows testing the
+// membership of a value in a set of its keys, e.g. whether a user is in a set
+// of user IDs.
+func ContainsBy[T comparable, K comparable](s Set[K], val T, key func(T) K) bool {
+	return s.Contains(key(val))
+}
+
+// AppendBy adds key(val) to s for every value of values.
+func AppendBy[T comparable, K comparable](s *Set[K], key func(T) K, values ...T) {
+	s.lazyInit()
+	for _, v := range values {
+		s.insert(key(v))
+	}
+}
+
+// Numeric is a constraint that permits any integer or floating-point type.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// ConvertNumeric converts the elements of s into another numeric type using
+// conv and returns a new Set of the results. It is like Map but restricted to
+// numeric types; conv is responsible for handling overflow and precision
+// loss. Elements that convert to the same value collapse into a single
+// element.
+func ConvertNumeric[From, To Numeric](s Set[From], conv func(From) To) Set[To] {
+	return Map(s, conv)
+}
+
+// Union returns a new Set that contains every element that is present in any
+// of sets. None of sets is modified. If sets is empty, Union returns an empty
+// set.
+func Union[T comparable](sets ...Set[T]) Set[T] {
+	n := 0
+	for _, s := range sets {
+		n += s.Len()
+	}
+	u := WithCapacity[T](n)
+	u.Merge(sets...)
+	return u
+}
+
+// Flatten returns a new Set that contains every element that is present in
+// any of sets. It is like the package-level Union but takes the sets as a
+// slice. None of sets is modified.
+func Flatten[T comparable](sets []Set[T]) Set[T] {
+	return Union(sets...)
+}
+
+// Intersection returns a new Set that contains only the elements that are
+// present in every one of sets. None of sets is modified. If sets is empty,
+// Intersection returns an empty set; if it holds a single set, Intersection
+// returns a clone of it.
+func Intersection[T comparable](sets ...Set[T]) Set[T] {
+	if len(sets) == 0 {
+		return Empty[T]()
+	}
+	smallest := 0
+	for i, s := range sets {
+		if s.Len() < sets[smallest].Len() {
+			smallest = i
+		}
+	}
+	acc := sets[smallest].Clone()
+	for i, s := range sets {
+		if acc.Len() == 0 {
+			break
+		}
+		if i != smallest {
+			acc.RetainAll(s)
+		}
+	}
+	return acc
+}
+
+// IntersectionAll returns a new Set that contains only the elements that are
+// present in every one of sets. The sets are processed in ascending order of
+// their lengths, so that the intermediate result starts as small as possible,
+// and processing stops as soon as it becomes empty. Neither sets nor its
+// elements are modified. If sets is empty, IntersectionAll returns an empty
+// set.
+func IntersectionAll[T comparable](sets []Set[T]) Set[T] {
+	if len(sets) == 0 {
+		return Empty[T]()
+	}
+	sorted := slices.Clone(sets)
+	slices.SortFunc(sorted, func(a, b Set[T]) int {
+		return cmp.Compare(a.Len(), b.Len())
+	})
+	acc := sorted[0].Clone()
+	for _, s := range sorted[1:] {
+		if acc.Len() == 0 {
+			break
+		}
+		acc.RetainAll(s)
+	}
+	return acc
+}
+
+// Disjoint reports whether sets are pairwise disjoint, which means that no
+// element is present in more than one of them. It returns true if sets holds
+// fewer than two sets.
+func Disjoint[T comparable](sets ...Set[T]) bool {
+	if len(sets) < 2 {
+		return true
+	}
+	var seen Set[T]
+	for _, s := range sets {
+		for v := range s.m {
+			if !seen.Add(v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Frequency returns, for every element that is present in any of sets, the
+// number of sets that contain it.
+func Frequency[T comparable](sets ...Set[T]) map[T]int {
+	freq := make(map[T]int)
+	for _, s := range sets {
+		for v := range s.m {
+			freq[v]++
+		}
+	}
+	return freq
+}
+
+// GroupBy splits s into groups of elements that share the same key. Every
+// element of s is added to the set that is stored under key(elem) in the
+// returned map.
+func GroupBy[T comparable, K comparable](s Set[T], key func(T) K) map[K]Set[T] {
+	groups := make(map[K]Set[T])
+	for v := range s.m {
+		k := key(v)
+		g, ok := groups[k]
+		if !ok {
+			g = Empty[T]()
+			groups[k] = g
+		}
+		g.m[v] = struct{}{}
+	}
+	return groups
+}
+
+// Reduce folds the elements of s into an accumulator, starting with init and
+// calling fn with the current accumulator and each element. The iteration
+// order is unspecified, so fn should be independent of the order to produce
+// deterministic results.
+func Reduce[T comparable, A any](s Set[T], init A, fn func(acc A, val T) A) A {
+	acc := init
+	for v := range s.m {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// EqualFold reports whether a and b are equal under simple Unicode case
+// folding, as defined by strings.EqualFold. Each element of a must fold to an
+// element of b and vice versa, so {"Go", "GO"} and {"go"} are considered
+// equal even though their lengths differ. No other normalization, such as
+// Unicode normalization forms, is applied.
+func EqualFold(a, b Set[string]) bool {
+	return Map(a, foldKey).Equal(Map(b, foldKey))
+}
+
+// foldKey maps every rune of s to the smallest rune of its case folding
+// orbit, so that two strings are equal under strings.EqualFold exactly if
+// their keys are equal.
+func foldKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		lo := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < lo {
+				lo = f
+			}
+		}
+		return lo
+	}, s)
+}
+
+// EqualApprox reports whether a and b contain the same number of elements and
+// every element of a can be matched with a distinct element of b that is at
+// most epsilon away from it. Matching is greedy: both sets are sorted and
+// their elements are paired in ascending order, which finds a matching
+// whenever one exists for one-dimensional values. Because tolerance is not
+// transitive, a set whose own elements lie within epsilon of each other may
+// compare equal to several different sets. NaN never matches any element.
+func EqualApprox(a, b Set[float64], epsilon float64) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	x, y := Sorted(a), Sorted(b)
+	for i := range x {
+		if !(math.Abs(x[i]-y[i]) <= epsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns a fingerprint of s that is computed from the hashes that
+// hashElem returns for its elements. The element hashes are combined with an
+// order-independent operation, so equal sets always produce the same
+// fingerprint regardless of how they were constructed.
+func Hash[T comparable](s Set[T], hashElem func(T) uint64) uint64 {
+	var h uint64
+	for v := range s.m {
+		h += mix64(hashElem(v))
+	}
+	return h
+}
+
+// mix64 scrambles the bits of h using the finalizer of SplitMix64, so that
+// similar element hashes do not cancel each other out when combined.
+func mix64(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// Min returns the smallest element of s. The boolean reports whether s
+// contained any element; if s is empty, Min returns the zero value of T and
+// false.
+func Min[T cmp.Ordered](s Set[T]) (T, bool) {
+	var (
+		best T
+		ok   bool
+	)
+	for v := range s.m {
+		if !ok || cmp.Less(v, best) {
+			best, ok = v, true
+		}
+	}
+	return best, ok
+}
+
+// Max returns the largest element of s. The boolean reports whether s
+// contained any element; if s is empty, Max returns the zero value of T and
+// false.
+func Max[T cmp.Ordered](s Set[T]) (T, bool) {
+	var (
+		best T
+		ok   bool
+	)
+	for v := range s.m {
+		if !ok || cmp.Less(best, v) {
+			best, ok = v, true
+		}
+	}
+	return best, ok
+}
+
+// Sorted returns the elements of s as a slice sorted in ascending order.
+func Sorted[T cmp.Ordered](s Set[T]) []T {
+	values := s.Slice()
+	slices.Sort(values)
+	return values
+}
+
+// Powerset returns all 1<<s.Len() subsets of s, including the empty set and a
+// copy of s itself. The number of subsets grows exponentially with the number
+// of elements, so Powerset is only practical for small sets. It panics if the
+// number of subsets does not fit into an int.
+func Powerset[T comparable](s Set[T]) []Set[T] {
+	values := s.Slice()
+	if len(values) >= strconv.IntSize-1 {
+		panic("set: powerset too large")
+	}
+	subsets := make([]Set[T], 1<<len(values))
+	for mask := range subsets {
+		subset := Empty[T]()
+		for i, v := range values {
+			if mask&(1<<i) != 0 {
+				subset.Append(v)
+			}
+		}
+		subsets[mask] = subset
+	}
+	return subsets
+}
+
+// Pair is an ordered pair of two values.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Product returns the cartesian product of a and b, which contains every pair
+// of an element of a and an element of b. The result has a.Len()*b.Len()
+// pairs and is empty if either a or b is empty.
+func Product[A, B comparable](a Set[A], b Set[B]) []Pair[A, B] {
+	pairs := make([]Pair[A, B], 0, a.Len()*b.Len())
+	for x := range a.m {
+		for y := range b.m {
+			pairs = append(pairs, Pair[A, B]{First: x, Second: y})
+		}
+	}
+	return pairs
+}
+
+// String formats s as "set{a, b, c}". The elements are formatted with
+// fmt.Sprint and sorted by their formatted representation, so equal sets
+// always produce the same string.
+func (s Set[T]) String() string {
+	elems := make([]string, 0, len(s.m))
+	for v := range s.m {
+		elems = append(elems, fmt.Sprint(v))
+	}
+	sort.Strings(elems)
+	return "set{" + strings.Join(elems, ", ") + "}"
+}
+
+// ToMap returns a new map whose keys are the elements of s. The map does not
+// share its storage with s.
+func (s Set[T]) ToMap() map[T]struct{} {
+	m := make(map[T]struct{}, len(s.m))
+	for v := range s.m {
+		m[v] = struct{}{}
+	}
+	return m
+}
+
 // MarshalJSON marshals s into a JSON array.
 func (s Set[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.Slice())
 }
 
-// UnmarshalJSON unmarshals a JSON array into s. The previous content of s is
-// cleared.
-func (s Set[T]) UnmarshalJSON(data []byte) error {
+// MarshalJSONSorted marshals s into a JSON array whose elements are sorted
+// according to less. Unlike MarshalJSON, the output is deterministic for equal
+// sets.
+func (s Set[T]) MarshalJSONSorted(less func(a, b T) bool) ([]byte, error) {
+	values := s.Slice()
+	sort.Slice(values, func(i, j int) bool {
+		return less(values[i], values[j])
+	})
+	return json.Marshal(values)
+}
+
+// MarshalJSONIndent is like MarshalJSON but applies json.MarshalIndent with
+// prefix and indent to format the array for human readers.
+func (s Set[T]) MarshalJSONIndent(prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(s.Slice(), prefix, indent)
+}
+
+// MarshalJSONObject marshals s into a JSON object whose keys are the elements
+// of s and whose values are all true, e.g. {"a":true,"b":true}. The elements
+// must be usable as JSON object keys, which means T must be a string or
+// integer type or implement encoding.TextMarshaler.
+func (s Set[T]) MarshalJSONObject() ([]byte, error) {
+	obj := make(map[T]bool, len(s.m))
+	for v := range s.m {
+		obj[v] = true
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSONObject unmarshals a JSON object, in the format written by
+// MarshalJSONObject, into s. Keys whose value is false are not added. The
+// previous content of s is cleared.
+func (s *Set[T]) UnmarshalJSONObject(data []byte) error {
 	s.Clear()
-	var values []T
-	if err := json.Unmarshal(data, &values); err != nil {
-		return err
+	var obj map[T]bool
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return &DecodeError{Format: "json", Index: -1, Err: err}
+	}
+	for v, ok := range obj {
+		if ok {
+			s.Append(v)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON unmarshals a JSON array into s. A JSON null unmarshals into
+// an empty set. The previous content of s is cleared.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	s.Clear()
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return &DecodeError{Format: "json", Index: -1, Err: err}
+	}
+	for i, elem := range elems {
+		var v T
+		if err := json.Unmarshal(elem, &v); err != nil {
+			return &DecodeError{Format: "json", Index: i, Err: err}
+		}
+		s.Append(v)
+	}
+	return nil
+}
+
+// DecodeJSON reads a JSON array from r and adds its elements to s one at a
+// time, without buffering the whole array in memory. Like UnmarshalJSON, it
+// accepts a JSON null as an empty set and rejects any data after the array.
+// The previous content of s is cleared. If an error occurs, s contains the
+// elements decoded so far.
+func (s *Set[T]) DecodeJSON(r io.Reader) error {
+	s.Clear()
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return &DecodeError{Format: "json", Index: -1, Err: err}
+	}
+	switch tok {
+	case nil:
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			var v T
+			if err := dec.Decode(&v); err != nil {
+				return &DecodeError{Format: "json", Index: i, Err: err}
+			}
+			s.Append(v)
+		}
+		if _, err := dec.Token(); err != nil {
+			return &DecodeError{Format: "json", Index: -1, Err: err}
+		}
+	default:
+		return &DecodeError{Format: "json", Index: -1, Err: fmt.Errorf("expected array, got %v", tok)}
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = errors.New("trailing data")
+		}
+		return &DecodeError{Format: "json", Index: -1, Err: err}
 	}
-	s.Append(values...)
 	return nil
 }
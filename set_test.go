@@ -0,0 +1,1878 @@
+package set
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSet_ZeroValueReaders(t *testing.T) {
+	var s, o Set[int]
+	if s.Len() != 0 || !s.IsEmpty() || s.Contains(1) {
+		t.Error("zero value must be empty")
+	}
+	if !s.ContainsAll() || s.ContainsAny(1) || s.CountPresent(1) != 0 {
+		t.Error("bulk membership checks on zero value")
+	}
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop on zero value must report false")
+	}
+	if len(s.Sample(1)) != 0 || len(s.SampleWith(rand.New(rand.NewSource(1)), 1)) != 0 {
+		t.Error("sampling zero value must yield nothing")
+	}
+	if len(s.SampleWeighted(func(int) float64 { return 1 }, 1, nil)) != 0 {
+		t.Error("SampleWeighted on zero value must yield nothing")
+	}
+	if len(s.Slice()) != 0 || len(s.SliceInto(nil)) != 0 || len(s.SliceSeeded(1, nil)) != 0 {
+		t.Error("slices of zero value must be empty")
+	}
+	for range s.All() {
+		t.Error("All on zero value must not yield")
+	}
+	for range s.Values() {
+		t.Error("Values on zero value must not yield")
+	}
+	s.ForEach(func(int) bool { t.Error("ForEach on zero value must not call fn"); return true })
+	pred := func(int) bool { return true }
+	if s.Any(pred) || !s.Every(pred) || s.Count(pred) != 0 {
+		t.Error("predicates on zero value")
+	}
+	if _, ok := s.Find(pred); ok {
+		t.Error("Find on zero value must report false")
+	}
+	for _, r := range []Set[int]{
+		s.Clone(), s.Union(o), s.Intersection(o), s.Difference(o),
+		s.SymmetricDifference(o), s.Complement(o), s.Filter(pred),
+		s.IntersectSlice([]int{1}), s.IntersectSeq(Of(1).All()),
+	} {
+		if r.Len() != 0 {
+			t.Errorf("derived set of zero value = %v, want empty", r)
+		}
+	}
+	if !s.UnionSeq(Of(1).All()).Equal(Of(1)) {
+		t.Error("UnionSeq of zero value must hold the yielded values")
+	}
+	if a, r := s.Diff(o); a.Len()+r.Len() != 0 {
+		t.Error("Diff of zero values must be empty")
+	}
+	if a, r := s.DiffCounts(o); a+r != 0 {
+		t.Error("DiffCounts of zero values must be zero")
+	}
+	if !s.IsSubset(o) || !s.IsSuperset(o) || !s.IsDisjoint(o) || !s.Equal(o) || s.ContainsAnyOf([]int{1}) {
+		t.Error("relations between zero values")
+	}
+	for range s.DifferenceIter(o) {
+		t.Error("DifferenceIter on zero value must not yield")
+	}
+	for range s.IntersectionIter(o) {
+		t.Error("IntersectionIter on zero value must not yield")
+	}
+	for range s.UnionIter(o) {
+		t.Error("UnionIter on zero values must not yield")
+	}
+	if s.Jaccard(o) != 1 || s.Overlap(o) != 1 || s.Dice(o) != 1 {
+		t.Error("similarity of two zero values must be 1")
+	}
+	if m, u := s.Partition(pred); m.Len()+u.Len() != 0 {
+		t.Error("Partition of zero value must be empty")
+	}
+	if len(s.Chunk(1)) != 0 {
+		t.Error("Chunk of zero value must be empty")
+	}
+	if s.String() != "set{}" || len(s.ToMap()) != 0 || s.Relation(o) != RelationEqual {
+		t.Errorf("String() = %q", s.String())
+	}
+	if b, err := s.MarshalJSON(); err != nil || string(b) != "[]" {
+		t.Errorf("MarshalJSON() = %s, %v", b, err)
+	}
+}
+
+func TestSet_ZeroValueMutators(t *testing.T) {
+	mutators := map[string]func(*Set[int]){
+		"Append":      func(s *Set[int]) { s.Append(1) },
+		"Add":         func(s *Set[int]) { s.Add(1) },
+		"AddAll":      func(s *Set[int]) { s.AddAll(1) },
+		"AppendSeq":   func(s *Set[int]) { s.AppendSeq(Of(1).All()) },
+		"AppendSlice": func(s *Set[int]) { s.AppendSlice([]int{1}) },
+		"Toggle":      func(s *Set[int]) { s.Toggle(1) },
+		"Merge":       func(s *Set[int]) { s.Merge(Of(1)) },
+		"SymmetricDifferenceInPlace": func(s *Set[int]) {
+			s.SymmetricDifferenceInPlace(Of(1))
+		},
+		"Grow":   func(s *Set[int]) { s.Grow(4); s.Append(1) },
+		"Shrink": func(s *Set[int]) { s.Shrink(); s.Append(1) },
+		"Clear":  func(s *Set[int]) { s.Clear(); s.Append(1) },
+		"With":   func(s *Set[int]) { *s = s.With(1) },
+	}
+	for name, mutate := range mutators {
+		t.Run(name, func(t *testing.T) {
+			var s Set[int]
+			mutate(&s)
+			if !s.Equal(Of(1)) {
+				t.Errorf("got %v, want {1}", s)
+			}
+		})
+	}
+
+	removers := map[string]func(Set[int]){
+		"Delete":       func(s Set[int]) { s.Delete(1) },
+		"Remove":       func(s Set[int]) { s.Remove(1) },
+		"RetainAll":    func(s Set[int]) { s.RetainAll(Of(1)) },
+		"RetainSlice":  func(s Set[int]) { s.RetainSlice([]int{1}) },
+		"SubtractAll":  func(s Set[int]) { s.SubtractAll(Of(1)) },
+		"DeleteFunc":   func(s Set[int]) { s.DeleteFunc(func(int) bool { return true }) },
+		"ClearInPlace": func(s Set[int]) { s.ClearInPlace() },
+		"Without":      func(s Set[int]) { s.Without(1) },
+	}
+	for name, remove := range removers {
+		t.Run(name, func(t *testing.T) {
+			var s Set[int]
+			remove(s)
+			if s.Len() != 0 {
+				t.Errorf("got %v, want {}", s)
+			}
+		})
+	}
+}
+
+func TestSet_ZeroValueUnmarshal(t *testing.T) {
+	var s Set[int]
+	if err := s.UnmarshalJSON([]byte("[1]")); err != nil || !s.Equal(Of(1)) {
+		t.Errorf("UnmarshalJSON() = %v, %v", s, err)
+	}
+}
+
+func TestSet_CopyAliasing(t *testing.T) {
+	var zero Set[int]
+	zc := zero
+	zc.Append(1)
+	if zero.Contains(1) {
+		t.Error("copies of a zero value must diverge once mutated")
+	}
+
+	allocated := Empty[int]()
+	ac := allocated
+	ac.Append(1)
+	if !allocated.Contains(1) {
+		t.Error("copies of an allocated set must share their elements")
+	}
+}
+
+func TestSet_CopyAliasingAfterMutation(t *testing.T) {
+	sharing := map[string]func(*Set[int]){
+		"Append":       func(s *Set[int]) { s.Append(9) },
+		"Add":          func(s *Set[int]) { s.Add(9) },
+		"AppendSlice":  func(s *Set[int]) { s.AppendSlice([]int{9, 10, 11}) },
+		"Delete":       func(s *Set[int]) { s.Delete(1) },
+		"ClearInPlace": func(s *Set[int]) { s.ClearInPlace() },
+	}
+	for name, mutate := range sharing {
+		t.Run(name, func(t *testing.T) {
+			a := Of(1, 2)
+			b := a
+			mutate(&b)
+			b.Append(42)
+			if !a.Contains(42) {
+				t.Errorf("%s must keep the internal map shared with copies", name)
+			}
+		})
+	}
+
+	detaching := map[string]func(*Set[int]){
+		"Clear":         func(s *Set[int]) { s.Clear() },
+		"Shrink":        func(s *Set[int]) { s.Shrink() },
+		"Grow":          func(s *Set[int]) { s.Grow(1) },
+		"UnmarshalJSON": func(s *Set[int]) { s.UnmarshalJSON([]byte("[1]")) },
+	}
+	for name, mutate := range detaching {
+		t.Run(name, func(t *testing.T) {
+			a := Of(1, 2)
+			b := a
+			mutate(&b)
+			b.Append(42)
+			if a.Contains(42) {
+				t.Errorf("%s must detach the set from its copies", name)
+			}
+		})
+	}
+}
+
+func TestSet_IterVariants(t *testing.T) {
+	a, b := Of(1, 2, 3), Of(2, 3, 4)
+	tests := []struct {
+		name string
+		seq  func(func(int) bool)
+		want Set[int]
+	}{
+		{"UnionIter", a.UnionIter(b), Of(1, 2, 3, 4)},
+		{"IntersectionIter", a.IntersectionIter(b), Of(2, 3)},
+		{"DifferenceIter", a.DifferenceIter(b), Of(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for v := range tt.seq {
+				got = append(got, v)
+			}
+			if len(got) != tt.want.Len() || !FromSlice(got).Equal(tt.want) {
+				t.Errorf("yielded %v, want %v", got, tt.want)
+			}
+
+			n := 0
+			for range tt.seq {
+				n++
+				break
+			}
+			if n != 1 {
+				t.Errorf("iteration did not stop after break")
+			}
+		})
+	}
+}
+
+func TestSet_SeqVariants(t *testing.T) {
+	s := Of(1, 2, 3)
+	if got := s.UnionSeq(Of(3, 4).All()); !got.Equal(Of(1, 2, 3, 4)) {
+		t.Errorf("UnionSeq() = %v, want {1, 2, 3, 4}", got)
+	}
+	if got := s.IntersectSeq(Of(3, 4).All()); !got.Equal(Of(3)) {
+		t.Errorf("IntersectSeq() = %v, want {3}", got)
+	}
+	if !s.Equal(Of(1, 2, 3)) {
+		t.Errorf("s was modified: %v", s)
+	}
+}
+
+func TestSet_AppendSlice(t *testing.T) {
+	s := Of(1, 2, 3)
+	s.AppendSlice([]int{3, 4})
+	s.AppendSlice([]int{5, 6, 7, 8, 9})
+	if !s.Equal(Of(1, 2, 3, 4, 5, 6, 7, 8, 9)) {
+		t.Errorf("AppendSlice() = %v", s)
+	}
+
+	var z Set[int]
+	z.AppendSlice(nil)
+	if z.Len() != 0 {
+		t.Errorf("AppendSlice(nil) = %v, want {}", z)
+	}
+}
+
+func TestSet_AppendSliceSharesMap(t *testing.T) {
+	a := Of(1)
+	b := a
+	b.AppendSlice([]int{2, 3, 4, 5})
+	if !a.Equal(Of(1, 2, 3, 4, 5)) {
+		t.Errorf("AppendSlice() on a copy left the original at %v", a)
+	}
+}
+
+func TestSet_AppendSliceKeepsCapacity(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	var s Set[int]
+	allocs := testing.AllocsPerRun(10, func() {
+		s = WithCapacity[int](len(values))
+	})
+	inserts := testing.AllocsPerRun(10, func() {
+		s = WithCapacity[int](len(values))
+		s.AppendSlice(values)
+	})
+	if inserts != allocs {
+		t.Errorf("AppendSlice() into a pre-sized set allocated %v times, want 0", inserts-allocs)
+	}
+}
+
+// sequence returns the integers 0 to n-1. It is shared by tests and
+// benchmarks that need many distinct elements.
+func sequence(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	return values
+}
+
+func BenchmarkSet_AppendSlice(b *testing.B) {
+	values := sequence(10000)
+	b.ReportAllocs()
+	for range b.N {
+		var s Set[int]
+		s.AppendSlice(values)
+	}
+}
+
+func BenchmarkSet_Append(b *testing.B) {
+	values := sequence(10000)
+	b.ReportAllocs()
+	for range b.N {
+		var s Set[int]
+		s.Append(values...)
+	}
+}
+
+func TestSet_DecodeJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Set[int]
+		wantErr bool
+	}{
+		{in: "[1, 2, 2, 3]", want: Of(1, 2, 3)},
+		{in: "[]", want: Empty[int]()},
+		{in: "null", want: Empty[int]()},
+		{in: " [1] \n", want: Of(1)},
+		{in: "[1] [2]", want: Of(1), wantErr: true},
+		{in: "null x", want: Empty[int](), wantErr: true},
+		{in: "{}", want: Empty[int](), wantErr: true},
+		{in: `[1, "a"]`, want: Of(1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			s := Of(42)
+			err := s.DecodeJSON(strings.NewReader(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !s.Equal(tt.want) {
+				t.Errorf("DecodeJSON() = %v, want %v", s, tt.want)
+			}
+
+			// UnmarshalJSON must agree with DecodeJSON on what it accepts.
+			var u Set[int]
+			if uerr := u.UnmarshalJSON([]byte(tt.in)); (uerr != nil) != tt.wantErr {
+				t.Errorf("UnmarshalJSON() error = %v, wantErr %v", uerr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSet_DecodeJSONPartial(t *testing.T) {
+	errRead := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("[1,2,3,"), iotest.ErrReader(errRead))
+
+	var s Set[int]
+	err := s.DecodeJSON(r)
+	var de *DecodeError
+	if !errors.As(err, &de) || !errors.Is(err, errRead) {
+		t.Fatalf("DecodeJSON() error = %v, want DecodeError wrapping %v", err, errRead)
+	}
+	if de.Index != 3 {
+		t.Errorf("Index = %d, want 3", de.Index)
+	}
+	if !s.Equal(Of(1, 2, 3)) {
+		t.Errorf("DecodeJSON() = %v, want the elements decoded so far", s)
+	}
+}
+
+func TestSet_DecodeJSONStreaming(t *testing.T) {
+	const n = 100000
+	pr, pw := io.Pipe()
+	go func() {
+		io.WriteString(pw, "[")
+		for i := range n {
+			if i > 0 {
+				io.WriteString(pw, ",")
+			}
+			fmt.Fprint(pw, i)
+		}
+		io.WriteString(pw, "]")
+		pw.Close()
+	}()
+
+	var s Set[int]
+	if err := s.DecodeJSON(pr); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != n || !s.Contains(0) || !s.Contains(n-1) {
+		t.Errorf("Len() = %d, want %d", s.Len(), n)
+	}
+}
+
+func TestSet_RetainSlice(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   Set[int]
+	}{
+		{"subset", []int{2, 3, 5}, Of(2, 3)},
+		{"empty", []int{}, Empty[int]()},
+		{"nil", nil, Empty[int]()},
+		{"superset", []int{0, 1, 2, 3, 4, 5}, Of(1, 2, 3, 4)},
+		{"disjoint", []int{7, 8}, Empty[int]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Of(1, 2, 3, 4)
+			s.RetainSlice(tt.values)
+			if !s.Equal(tt.want) {
+				t.Errorf("RetainSlice() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet_RetainSliceEmptyAllocs(t *testing.T) {
+	s := Of(1, 2, 3)
+	if n := testing.AllocsPerRun(10, func() { s.RetainSlice(nil) }); n != 0 {
+		t.Errorf("RetainSlice(nil) allocated %v times, want 0", n)
+	}
+}
+
+func TestSet_Slice(t *testing.T) {
+	s := Of(3, 1, 2)
+	got := s.Slice()
+	if len(got) != 3 {
+		t.Fatalf("len(Slice()) = %d, want 3", len(got))
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Slice() = %v, want [1 2 3] in any order", got)
+	}
+	if got := Empty[int]().Slice(); len(got) != 0 {
+		t.Errorf("Slice() of empty set = %v, want []", got)
+	}
+}
+
+func TestSet_UnmarshalJSON(t *testing.T) {
+	s := Of(42)
+	if err := json.Unmarshal([]byte("[1, 2, 2]"), &s); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(Of(1, 2)) {
+		t.Errorf("Unmarshal() = %v, want {1, 2}", s)
+	}
+
+	var v struct{ S Set[string] }
+	if err := json.Unmarshal([]byte(`{"S": ["a"]}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.S.Equal(Of("a")) {
+		t.Errorf("Unmarshal() into field = %v, want {a}", v.S)
+	}
+
+	var de *DecodeError
+	if err := json.Unmarshal([]byte(`[1, "x"]`), &s); !errors.As(err, &de) || de.Index != 1 {
+		t.Errorf("Unmarshal() error = %v, want DecodeError at index 1", err)
+	}
+}
+
+func TestSet_Clear(t *testing.T) {
+	s := Of(1, 2)
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Clear() left %v", s)
+	}
+	s.Append(3)
+	if !s.Equal(Of(3)) {
+		t.Errorf("Append() after Clear() = %v, want {3}", s)
+	}
+}
+
+func TestSet_Union(t *testing.T) {
+	a, b := Of(1, 2), Of(2, 3)
+	if got := a.Union(b); !got.Equal(Of(1, 2, 3)) {
+		t.Errorf("Union() = %v, want {1, 2, 3}", got)
+	}
+	if !a.Equal(Of(1, 2)) || !b.Equal(Of(2, 3)) {
+		t.Errorf("Union() modified its operands: %v, %v", a, b)
+	}
+	if got := a.Union(Set[int]{}); !got.Equal(a) {
+		t.Errorf("Union() with zero value = %v, want %v", got, a)
+	}
+	if got := Of(1, 2).Union(Of(3, 4)); !got.Equal(Of(1, 2, 3, 4)) {
+		t.Errorf("Union() of disjoint sets = %v, want {1, 2, 3, 4}", got)
+	}
+	if got := a.Union(Of(2, 1)); !got.Equal(a) {
+		t.Errorf("Union() of equal sets = %v, want %v", got, a)
+	}
+}
+
+func TestSet_Intersection(t *testing.T) {
+	a, b := Of(1, 2, 3), Of(2, 3, 4, 5)
+	if got := a.Intersection(b); !got.Equal(Of(2, 3)) {
+		t.Errorf("Intersection() = %v, want {2, 3}", got)
+	}
+	if got := b.Intersection(a); !got.Equal(Of(2, 3)) {
+		t.Errorf("Intersection() reversed = %v, want {2, 3}", got)
+	}
+	if got := a.Intersection(Of(9)); got.Len() != 0 {
+		t.Errorf("Intersection() of disjoint sets = %v, want {}", got)
+	}
+	if !a.Equal(Of(1, 2, 3)) || !b.Equal(Of(2, 3, 4, 5)) {
+		t.Errorf("Intersection() modified its operands: %v, %v", a, b)
+	}
+	if got := a.Intersection(Of(3, 2, 1)); !got.Equal(a) {
+		t.Errorf("Intersection() of equal sets = %v, want %v", got, a)
+	}
+	if got := a.Intersection(Empty[int]()); got.Len() != 0 {
+		t.Errorf("Intersection() with empty set = %v, want {}", got)
+	}
+	if got := Empty[int]().Intersection(a); got.Len() != 0 {
+		t.Errorf("Intersection() of empty set = %v, want {}", got)
+	}
+}
+
+func TestSet_Difference(t *testing.T) {
+	a, b := Of(1, 2, 3), Of(2, 4)
+	if got := a.Difference(b); !got.Equal(Of(1, 3)) {
+		t.Errorf("a.Difference(b) = %v, want {1, 3}", got)
+	}
+	if got := b.Difference(a); !got.Equal(Of(4)) {
+		t.Errorf("b.Difference(a) = %v, want {4}", got)
+	}
+	if !a.Equal(Of(1, 2, 3)) || !b.Equal(Of(2, 4)) {
+		t.Errorf("Difference() modified its operands: %v, %v", a, b)
+	}
+	if got := a.Difference(a); got.Len() != 0 {
+		t.Errorf("a.Difference(a) = %v, want {}", got)
+	}
+	if got := a.Difference(Empty[int]()); !got.Equal(a) {
+		t.Errorf("a.Difference({}) = %v, want %v", got, a)
+	}
+	if got := a.Difference(Of(0, 1, 2, 3, 4)); got.Len() != 0 {
+		t.Errorf("a.Difference(superset) = %v, want {}", got)
+	}
+}
+
+func TestSet_SymmetricDifference(t *testing.T) {
+	a, b := Of(1, 2, 3), Of(2, 3, 4)
+	if got := a.SymmetricDifference(b); !got.Equal(Of(1, 4)) {
+		t.Errorf("SymmetricDifference() = %v, want {1, 4}", got)
+	}
+	if got := b.SymmetricDifference(a); !got.Equal(Of(1, 4)) {
+		t.Errorf("SymmetricDifference() reversed = %v, want {1, 4}", got)
+	}
+	if got := a.SymmetricDifference(a); got.Len() != 0 {
+		t.Errorf("SymmetricDifference() with itself = %v, want {}", got)
+	}
+	if got := a.SymmetricDifference(Empty[int]()); !got.Equal(a) {
+		t.Errorf("SymmetricDifference() with empty set = %v, want %v", got, a)
+	}
+	if got := Empty[int]().SymmetricDifference(a); !got.Equal(a) {
+		t.Errorf("SymmetricDifference() of empty set = %v, want %v", got, a)
+	}
+	if got := Empty[int]().SymmetricDifference(Empty[int]()); got.Len() != 0 {
+		t.Errorf("SymmetricDifference() of empty sets = %v, want {}", got)
+	}
+}
+
+func TestSet_SubsetSupersetDisjoint(t *testing.T) {
+	tests := []struct {
+		a, b                       Set[int]
+		subset, superset, disjoint bool
+	}{
+		{Of(1, 2), Of(1, 2, 3), true, false, false},
+		{Of(1, 2, 3), Of(1, 2), false, true, false},
+		{Of(1, 2), Of(1, 2), true, true, false},
+		{Of(1, 2), Of(3, 4), false, false, true},
+		{Empty[int](), Of(1), true, false, true},
+		{Empty[int](), Empty[int](), true, true, true},
+	}
+	for _, tt := range tests {
+		if got := tt.a.IsSubset(tt.b); got != tt.subset {
+			t.Errorf("%v.IsSubset(%v) = %v, want %v", tt.a, tt.b, got, tt.subset)
+		}
+		if got := tt.a.IsSuperset(tt.b); got != tt.superset {
+			t.Errorf("%v.IsSuperset(%v) = %v, want %v", tt.a, tt.b, got, tt.superset)
+		}
+		if got := tt.a.IsDisjoint(tt.b); got != tt.disjoint {
+			t.Errorf("%v.IsDisjoint(%v) = %v, want %v", tt.a, tt.b, got, tt.disjoint)
+		}
+	}
+}
+
+func TestSet_Equal(t *testing.T) {
+	tests := []struct {
+		a, b Set[int]
+		want bool
+	}{
+		{Of(1, 2), Of(2, 1), true},
+		{Of(1, 2), Of(1, 2, 3), false},
+		{Of(1, 2), Of(1, 3), false},
+		{Empty[int](), Set[int]{}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b); got != tt.want {
+			t.Errorf("%v.Equal(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.b.Equal(tt.a); got != tt.want {
+			t.Errorf("%v.Equal(%v) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestSet_Clone(t *testing.T) {
+	s := Of(1, 2)
+	c := s.Clone()
+	if !c.Equal(s) {
+		t.Fatalf("Clone() = %v, want %v", c, s)
+	}
+	c.Append(3)
+	s.Delete(1)
+	if !s.Equal(Of(2)) || !c.Equal(Of(1, 2, 3)) {
+		t.Errorf("clone shares storage: s = %v, c = %v", s, c)
+	}
+}
+
+func TestSet_Merge(t *testing.T) {
+	s := Of(1)
+	o := Of(2, 3)
+	s.Merge(o, Of(3, 4), Set[int]{})
+	if !s.Equal(Of(1, 2, 3, 4)) {
+		t.Errorf("Merge() = %v, want {1, 2, 3, 4}", s)
+	}
+	if !o.Equal(Of(2, 3)) {
+		t.Errorf("Merge() modified its argument: %v", o)
+	}
+}
+
+func TestSet_RetainAll(t *testing.T) {
+	s := Of(1, 2, 3, 4)
+	o := Of(2, 4, 6)
+	s.RetainAll(o)
+	if !s.Equal(Of(2, 4)) {
+		t.Errorf("RetainAll() = %v, want {2, 4}", s)
+	}
+	if !o.Equal(Of(2, 4, 6)) {
+		t.Errorf("RetainAll() modified its argument: %v", o)
+	}
+	s.RetainAll(Set[int]{})
+	if s.Len() != 0 {
+		t.Errorf("RetainAll() with empty set = %v, want {}", s)
+	}
+	d := Of(1, 2)
+	d.RetainAll(Of(3, 4))
+	if d.Len() != 0 {
+		t.Errorf("RetainAll() with disjoint set = %v, want {}", d)
+	}
+	p := Of(1, 2)
+	p.RetainAll(Of(0, 1, 2, 3))
+	if !p.Equal(Of(1, 2)) {
+		t.Errorf("RetainAll() with superset = %v, want {1, 2}", p)
+	}
+}
+
+func TestSet_DeleteFunc(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	if n := s.DeleteFunc(func(v int) bool { return v%2 == 0 }); n != 2 {
+		t.Errorf("DeleteFunc() = %d, want 2", n)
+	}
+	if !s.Equal(Of(1, 3, 5)) {
+		t.Errorf("DeleteFunc() left %v, want {1, 3, 5}", s)
+	}
+	if n := s.DeleteFunc(func(int) bool { return false }); n != 0 {
+		t.Errorf("DeleteFunc() = %d, want 0", n)
+	}
+	if n := s.DeleteFunc(func(int) bool { return true }); n != 3 {
+		t.Errorf("DeleteFunc() = %d, want 3", n)
+	}
+	if s.Len() != 0 {
+		t.Errorf("DeleteFunc() left %v, want {}", s)
+	}
+}
+
+func TestSet_Filter(t *testing.T) {
+	s := Of(1, 2, 3, 4)
+	got := s.Filter(func(v int) bool { return v > 2 })
+	if !got.Equal(Of(3, 4)) {
+		t.Errorf("Filter() = %v, want {3, 4}", got)
+	}
+	if !s.Equal(Of(1, 2, 3, 4)) {
+		t.Errorf("Filter() modified s: %v", s)
+	}
+	if got := s.Filter(func(int) bool { return false }); got.Len() != 0 {
+		t.Errorf("Filter() with always-false predicate = %v, want {}", got)
+	}
+	all := s.Filter(func(int) bool { return true })
+	if !all.Equal(s) {
+		t.Errorf("Filter() with always-true predicate = %v, want %v", all, s)
+	}
+	all.Append(5)
+	if s.Contains(5) {
+		t.Error("Filter() must return a set with its own map")
+	}
+}
+
+func TestMap(t *testing.T) {
+	got := Map(Of(1, 2, 3), strconv.Itoa)
+	if !got.Equal(Of("1", "2", "3")) {
+		t.Errorf("Map() = %v, want {1, 2, 3}", got)
+	}
+
+	collapsed := Map(Of(-2, -1, 1, 2), func(v int) int { return v * v })
+	if !collapsed.Equal(Of(1, 4)) {
+		t.Errorf("Map() = %v, want {1, 4}", collapsed)
+	}
+}
+
+func TestSet_All(t *testing.T) {
+	s := Of(1, 2, 3)
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All() yielded %v, want [1 2 3]", got)
+	}
+
+	n := 0
+	for range s.All() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("All() yielded %d values before break, want 1", n)
+	}
+}
+
+func TestSet_AppendSeq(t *testing.T) {
+	s := Of(1)
+	s.AppendSeq(slices.Values([]int{1, 2, 3, 3}))
+	if !s.Equal(Of(1, 2, 3)) {
+		t.Errorf("AppendSeq() = %v, want {1, 2, 3}", s)
+	}
+
+	var z Set[int]
+	z.AppendSeq(Of(4, 5).All())
+	if !z.Equal(Of(4, 5)) {
+		t.Errorf("AppendSeq() on zero value = %v, want {4, 5}", z)
+	}
+}
+
+func TestSet_String(t *testing.T) {
+	tests := []struct {
+		s    fmt.Stringer
+		want string
+	}{
+		{Empty[int](), "set{}"},
+		{Of(3, 1, 2), "set{1, 2, 3}"},
+		{Of("b", "a"), "set{a, b}"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+	if got := fmt.Sprint(Of(1)); got != "set{1}" {
+		t.Errorf("Sprint() = %q, want %q", got, "set{1}")
+	}
+}
+
+func TestWithCapacity(t *testing.T) {
+	s := WithCapacity[int](16)
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", s.Len())
+	}
+	s.Append(1, 2)
+	if !s.Equal(Of(1, 2)) {
+		t.Errorf("Append() = %v, want {1, 2}", s)
+	}
+}
+
+func BenchmarkOf(b *testing.B) {
+	values := sequence(10000)
+	b.ReportAllocs()
+	for range b.N {
+		Of(values...)
+	}
+}
+
+func BenchmarkOfWithoutPresizing(b *testing.B) {
+	values := sequence(10000)
+	b.ReportAllocs()
+	for range b.N {
+		s := Empty[int]()
+		s.Append(values...)
+	}
+}
+
+func TestSet_Pop(t *testing.T) {
+	s := Of(1, 2)
+	seen := Empty[int]()
+	for range 2 {
+		v, ok := s.Pop()
+		if !ok {
+			t.Fatal("Pop() reported an empty set")
+		}
+		seen.Append(v)
+	}
+	if !seen.Equal(Of(1, 2)) || s.Len() != 0 {
+		t.Errorf("Pop() returned %v and left %v", seen, s)
+	}
+	if v, ok := s.Pop(); ok || v != 0 {
+		t.Errorf("Pop() on empty set = %v, %v, want 0, false", v, ok)
+	}
+}
+
+func TestSet_Add(t *testing.T) {
+	var s Set[string]
+	if !s.Add("a") {
+		t.Error("Add() of a new value = false, want true")
+	}
+	if s.Add("a") {
+		t.Error("Add() of a present value = true, want false")
+	}
+	if !s.Equal(Of("a")) {
+		t.Errorf("Add() = %v, want {a}", s)
+	}
+}
+
+func TestSet_Remove(t *testing.T) {
+	s := Of(1, 2)
+	if !s.Remove(1) {
+		t.Error("Remove() of a present value = false, want true")
+	}
+	if s.Remove(1) {
+		t.Error("Remove() of an absent value = true, want false")
+	}
+	s.Delete(2, 3)
+	if s.Len() != 0 {
+		t.Errorf("Delete() left %v", s)
+	}
+}
+
+func TestSet_ContainsAllAny(t *testing.T) {
+	s := Of(1, 2, 3)
+	tests := []struct {
+		values   []int
+		all, any bool
+	}{
+		{[]int{1, 2}, true, true},
+		{[]int{1, 4}, false, true},
+		{[]int{4, 5}, false, false},
+		{nil, true, false},
+	}
+	for _, tt := range tests {
+		if got := s.ContainsAll(tt.values...); got != tt.all {
+			t.Errorf("ContainsAll(%v) = %v, want %v", tt.values, got, tt.all)
+		}
+		if got := s.ContainsAny(tt.values...); got != tt.any {
+			t.Errorf("ContainsAny(%v) = %v, want %v", tt.values, got, tt.any)
+		}
+	}
+}
+
+func TestSet_MarshalJSONSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for range 10 {
+		b, err := Of(3, 1, 2, 5, 4).MarshalJSONSorted(less)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "[1,2,3,4,5]" {
+			t.Fatalf("MarshalJSONSorted() = %s, want [1,2,3,4,5]", b)
+		}
+	}
+	if b, _ := Empty[int]().MarshalJSONSorted(less); string(b) != "[]" {
+		t.Errorf("MarshalJSONSorted() of empty set = %s, want []", b)
+	}
+}
+
+func TestUnion(t *testing.T) {
+	if got := Union(Of(1), Of(1, 2), Of(3)); !got.Equal(Of(1, 2, 3)) {
+		t.Errorf("Union() = %v, want {1, 2, 3}", got)
+	}
+	if got := Union[int](); got.Len() != 0 {
+		t.Errorf("Union() of no sets = %v, want {}", got)
+	}
+	a := Of(1)
+	u := Union(a)
+	u.Append(2)
+	if a.Contains(2) {
+		t.Error("Union() of a single set must not share storage with it")
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	tests := []struct {
+		sets []Set[int]
+		want Set[int]
+	}{
+		{[]Set[int]{Of(1, 2, 3), Of(2, 3, 4), Of(3, 2)}, Of(2, 3)},
+		{[]Set[int]{Of(1, 2), Of(3)}, Empty[int]()},
+		{[]Set[int]{Of(1, 2)}, Of(1, 2)},
+		{nil, Empty[int]()},
+	}
+	for _, tt := range tests {
+		if got := Intersection(tt.sets...); !got.Equal(tt.want) {
+			t.Errorf("Intersection(%v) = %v, want %v", tt.sets, got, tt.want)
+		}
+	}
+
+	a := Of(1, 2)
+	i := Intersection(a)
+	i.Delete(1)
+	if !a.Contains(1) {
+		t.Error("Intersection() of a single set must not share storage with it")
+	}
+}
+
+func TestSet_Jaccard(t *testing.T) {
+	tests := []struct {
+		a, b Set[int]
+		want float64
+	}{
+		{Of(1, 2, 3), Of(2, 3, 4), 0.5},
+		{Of(1, 2), Of(1, 2), 1},
+		{Of(1), Of(2), 0},
+		{Of(1), Empty[int](), 0},
+		{Empty[int](), Empty[int](), 1},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Jaccard(tt.b); got != tt.want {
+			t.Errorf("%v.Jaccard(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSet_ForEach(t *testing.T) {
+	s := Of(1, 2, 3)
+	sum := 0
+	s.ForEach(func(v int) bool {
+		sum += v
+		return true
+	})
+	if sum != 6 {
+		t.Errorf("ForEach() visited a sum of %d, want 6", sum)
+	}
+
+	calls := 0
+	s.ForEach(func(int) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("ForEach() called fn %d times after it returned false, want 1", calls)
+	}
+}
+
+func TestSet_AnyEvery(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	tests := []struct {
+		s          Set[int]
+		any, every bool
+	}{
+		{Of(2, 4), true, true},
+		{Of(1, 2), true, false},
+		{Of(1, 3), false, false},
+		{Empty[int](), false, true},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Any(even); got != tt.any {
+			t.Errorf("%v.Any() = %v, want %v", tt.s, got, tt.any)
+		}
+		if got := tt.s.Every(even); got != tt.every {
+			t.Errorf("%v.Every() = %v, want %v", tt.s, got, tt.every)
+		}
+	}
+}
+
+func TestSet_Count(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	if got := s.Count(func(v int) bool { return v > 2 }); got != 3 {
+		t.Errorf("Count() = %d, want 3", got)
+	}
+	if got := s.Count(func(int) bool { return false }); got != 0 {
+		t.Errorf("Count() = %d, want 0", got)
+	}
+	if got := s.Count(func(int) bool { return true }); got != s.Len() {
+		t.Errorf("Count() = %d, want %d", got, s.Len())
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	s := Of(3, -1, 7, 2)
+	if v, ok := Min(s); !ok || v != -1 {
+		t.Errorf("Min() = %v, %v, want -1, true", v, ok)
+	}
+	if v, ok := Max(s); !ok || v != 7 {
+		t.Errorf("Max() = %v, %v, want 7, true", v, ok)
+	}
+	if v, ok := Min(Empty[string]()); ok || v != "" {
+		t.Errorf("Min() of empty set = %q, %v, want \"\", false", v, ok)
+	}
+	if v, ok := Max(Empty[int]()); ok || v != 0 {
+		t.Errorf("Max() of empty set = %v, %v, want 0, false", v, ok)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	if got := Sorted(Of(3, 1, 2)); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Sorted() = %v, want [1 2 3]", got)
+	}
+	if got := Sorted(Of("b", "c", "a")); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Sorted() = %v, want [a b c]", got)
+	}
+	if got := Sorted(Empty[int]()); len(got) != 0 {
+		t.Errorf("Sorted() of empty set = %v, want []", got)
+	}
+}
+
+func TestSet_Complement(t *testing.T) {
+	universe := Of(1, 2, 3, 4)
+	if got := Of(1, 3, 9).Complement(universe); !got.Equal(Of(2, 4)) {
+		t.Errorf("Complement() = %v, want {2, 4}", got)
+	}
+	if got := Empty[int]().Complement(universe); !got.Equal(universe) {
+		t.Errorf("Complement() of empty set = %v, want %v", got, universe)
+	}
+	if got := Of(1, 2).Complement(Empty[int]()); got.Len() != 0 {
+		t.Errorf("Complement() in empty universe = %v, want {}", got)
+	}
+}
+
+func TestSet_Diff(t *testing.T) {
+	before, after := Of(1, 2, 3), Of(2, 3, 4, 5)
+	added, removed := before.Diff(after)
+	if !added.Equal(Of(4, 5)) || !removed.Equal(Of(1)) {
+		t.Errorf("Diff() = %v, %v, want {4, 5}, {1}", added, removed)
+	}
+	added, removed = before.Diff(before)
+	if added.Len() != 0 || removed.Len() != 0 {
+		t.Errorf("Diff() with itself = %v, %v, want {}, {}", added, removed)
+	}
+	added, removed = Empty[int]().Diff(Empty[int]())
+	if added.Len() != 0 || removed.Len() != 0 {
+		t.Errorf("Diff() of empty sets = %v, %v, want {}, {}", added, removed)
+	}
+	added, removed = Of(1).Diff(Of(1, 2, 3))
+	if !added.Equal(Of(2, 3)) || removed.Len() != 0 {
+		t.Errorf("Diff() = %v, %v, want {2, 3}, {}", added, removed)
+	}
+	added, removed = Of(1, 2, 3).Diff(Of(2))
+	if added.Len() != 0 || !removed.Equal(Of(1, 3)) {
+		t.Errorf("Diff() = %v, %v, want {}, {1, 3}", added, removed)
+	}
+}
+
+func TestPowerset(t *testing.T) {
+	subsets := Powerset(Of(1, 2, 3))
+	if len(subsets) != 8 {
+		t.Fatalf("len(Powerset()) = %d, want 8", len(subsets))
+	}
+	seen := Empty[string]()
+	for _, s := range subsets {
+		if !s.IsSubset(Of(1, 2, 3)) {
+			t.Errorf("%v is not a subset", s)
+		}
+		seen.Append(s.String())
+	}
+	if seen.Len() != 8 {
+		t.Errorf("Powerset() contains duplicates: %v", seen)
+	}
+
+	if got := Powerset(Empty[int]()); len(got) != 1 || got[0].Len() != 0 {
+		t.Errorf("Powerset() of empty set = %v, want [{}]", got)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	pairs := Product(Of(1, 2), Of("a", "b", "c"))
+	if len(pairs) != 6 {
+		t.Fatalf("len(Product()) = %d, want 6", len(pairs))
+	}
+	if got := FromSlice(pairs); got.Len() != 6 || !got.Contains(Pair[int, string]{2, "c"}) {
+		t.Errorf("Product() = %v, want every pair exactly once", pairs)
+	}
+	if got := Product(Of(1), Empty[string]()); len(got) != 0 {
+		t.Errorf("Product() with empty set = %v, want []", got)
+	}
+}
+
+func TestSet_Partition(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	matched, unmatched := s.Partition(func(v int) bool { return v%2 == 0 })
+	if !matched.Equal(Of(2, 4)) || !unmatched.Equal(Of(1, 3, 5)) {
+		t.Errorf("Partition() = %v, %v, want {2, 4}, {1, 3, 5}", matched, unmatched)
+	}
+	if !s.Equal(Of(1, 2, 3, 4, 5)) {
+		t.Errorf("Partition() modified s: %v", s)
+	}
+}
+
+func TestSet_IsEmpty(t *testing.T) {
+	if !Empty[int]().IsEmpty() || Of(1).IsEmpty() {
+		t.Error("IsEmpty() must report whether the set has no elements")
+	}
+}
+
+func TestSet_Toggle(t *testing.T) {
+	s := Of(1)
+	if s.Toggle(1) || s.Contains(1) {
+		t.Error("Toggle() of a present value must remove it and report false")
+	}
+	if !s.Toggle(2) || !s.Contains(2) {
+		t.Error("Toggle() of an absent value must add it and report true")
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := Reduce(Of(1, 2, 3), 0, func(acc, v int) int { return acc + v })
+	if sum != 6 {
+		t.Errorf("Reduce() = %d, want 6", sum)
+	}
+	if got := Reduce(Empty[int](), "init", func(acc string, _ int) string { return acc + "!" }); got != "init" {
+		t.Errorf("Reduce() of empty set = %q, want %q", got, "init")
+	}
+}
+
+func TestFromSliceAndKeys(t *testing.T) {
+	values := []int{1, 2, 2}
+	if got := FromSlice(values); !got.Equal(Of(1, 2)) {
+		t.Errorf("FromSlice() = %v, want {1, 2}", got)
+	}
+	if got := FromKeys(map[string]int{"a": 1, "b": 0}); !got.Equal(Of("a", "b")) {
+		t.Errorf("FromKeys() = %v, want {a, b}", got)
+	}
+	if got := FromKeys[string, bool](nil); got.Len() != 0 {
+		t.Errorf("FromKeys(nil) = %v, want {}", got)
+	}
+}
+
+func TestSet_ToMap(t *testing.T) {
+	s := Of(1, 2)
+	m := s.ToMap()
+	if len(m) != 2 {
+		t.Fatalf("ToMap() = %v, want 2 keys", m)
+	}
+	for _, v := range []int{1, 2} {
+		if _, ok := m[v]; !ok {
+			t.Errorf("ToMap() is missing %d", v)
+		}
+	}
+	m[3] = struct{}{}
+	if s.Contains(3) {
+		t.Error("ToMap() must not share storage with s")
+	}
+}
+
+func TestSet_Sample(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{-1, 0, 3, 5, 10} {
+		got := s.SampleWith(r, n)
+		want := max(0, min(n, s.Len()))
+		if len(got) != want {
+			t.Errorf("SampleWith(%d) returned %d elements, want %d", n, len(got), want)
+		}
+		if sample := FromSlice(got); sample.Len() != len(got) || !sample.IsSubset(s) {
+			t.Errorf("SampleWith(%d) = %v, want distinct elements of s", n, got)
+		}
+	}
+	if got := s.Sample(2); len(got) != 2 {
+		t.Errorf("Sample(2) = %v, want 2 elements", got)
+	}
+}
+
+func TestSet_Shrink(t *testing.T) {
+	s := Of(sequence(1000)...)
+	s.DeleteFunc(func(v int) bool { return v >= 10 })
+	shared := s
+	s.Shrink()
+	if !s.Equal(Of(sequence(10)...)) {
+		t.Errorf("Shrink() = %v, want 0 to 9", s)
+	}
+	s.Append(-1)
+	if shared.Contains(-1) {
+		t.Error("Shrink() must replace the internal map")
+	}
+}
+
+func TestSet_JSONObject(t *testing.T) {
+	b, err := Of("b", "a").MarshalJSONObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"a":true,"b":true}` {
+		t.Errorf("MarshalJSONObject() = %s", b)
+	}
+
+	s := Of("stale")
+	if err := s.UnmarshalJSONObject([]byte(`{"a":true,"b":false,"c":true}`)); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(Of("a", "c")) {
+		t.Errorf("UnmarshalJSONObject() = %v, want {a, c}", s)
+	}
+
+	var ints Set[int]
+	if err := ints.UnmarshalJSONObject([]byte(`{"1":true,"2":true}`)); err != nil || !ints.Equal(Of(1, 2)) {
+		t.Errorf("UnmarshalJSONObject() = %v, %v, want {1, 2}", ints, err)
+	}
+	var de *DecodeError
+	if err := ints.UnmarshalJSONObject([]byte(`[1]`)); !errors.As(err, &de) {
+		t.Errorf("UnmarshalJSONObject() error = %v, want DecodeError", err)
+	}
+}
+
+func TestSet_OverlapDice(t *testing.T) {
+	tests := []struct {
+		a, b          Set[int]
+		overlap, dice float64
+	}{
+		{Of(1, 2), Of(1, 2, 3, 4), 1, 2.0 * 2 / 6},
+		{Of(1, 2, 3, 4), Of(3, 4, 5, 6), 0.5, 0.5},
+		{Of(1), Of(2), 0, 0},
+		{Of(1), Empty[int](), 0, 0},
+		{Empty[int](), Empty[int](), 1, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Overlap(tt.b); got != tt.overlap {
+			t.Errorf("%v.Overlap(%v) = %v, want %v", tt.a, tt.b, got, tt.overlap)
+		}
+		if got := tt.a.Dice(tt.b); got != tt.dice {
+			t.Errorf("%v.Dice(%v) = %v, want %v", tt.a, tt.b, got, tt.dice)
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy(Of(1, 2, 3, 4, 5), func(v int) bool { return v%2 == 0 })
+	if len(groups) != 2 {
+		t.Fatalf("GroupBy() = %v, want 2 groups", groups)
+	}
+	if !groups[true].Equal(Of(2, 4)) || !groups[false].Equal(Of(1, 3, 5)) {
+		t.Errorf("GroupBy() = %v", groups)
+	}
+	if got := GroupBy(Empty[int](), func(v int) int { return v }); len(got) != 0 {
+		t.Errorf("GroupBy() of empty set = %v, want no groups", got)
+	}
+}
+
+func TestSet_SliceHelpers(t *testing.T) {
+	s := Of(1, 2, 3)
+	if !s.ContainsAnyOf([]int{9, 3}) || s.ContainsAnyOf([]int{8, 9}) || s.ContainsAnyOf(nil) {
+		t.Error("ContainsAnyOf() must report whether any value is present")
+	}
+	if got := s.IntersectSlice([]int{2, 3, 3, 4}); !got.Equal(Of(2, 3)) {
+		t.Errorf("IntersectSlice() = %v, want {2, 3}", got)
+	}
+	if got := s.IntersectSlice(nil); got.Len() != 0 {
+		t.Errorf("IntersectSlice(nil) = %v, want {}", got)
+	}
+}
+
+func TestHash(t *testing.T) {
+	hashInt := func(v int) uint64 { return uint64(v) * 0x9e3779b97f4a7c15 }
+
+	a := Of(1, 2, 3)
+	b := Empty[int]()
+	for _, v := range []int{3, 2, 1, 2} {
+		b.Append(v)
+	}
+	if Hash(a, hashInt) != Hash(b, hashInt) {
+		t.Error("equal sets must have equal hashes")
+	}
+	if Hash(a, hashInt) == Hash(Of(1, 2), hashInt) {
+		t.Error("different sets should have different hashes")
+	}
+
+	identity := func(v int) uint64 { return uint64(v) }
+	if Hash(Of(1, 2), identity) == Hash(Of(3), identity) {
+		t.Error("mixing must keep additive element hashes from colliding")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	if got := Flatten([]Set[int]{Of(1), Of(1, 2), Empty[int]()}); !got.Equal(Of(1, 2)) {
+		t.Errorf("Flatten() = %v, want {1, 2}", got)
+	}
+	if got := Flatten[int](nil); got.Len() != 0 {
+		t.Errorf("Flatten(nil) = %v, want {}", got)
+	}
+}
+
+func TestSet_SubtractAll(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5, 6)
+	small, large := Of(1), Of(2, 3, 7, 8, 9, 10, 11)
+	s.SubtractAll(small, large, Set[int]{})
+	if !s.Equal(Of(4, 5, 6)) {
+		t.Errorf("SubtractAll() = %v, want {4, 5, 6}", s)
+	}
+	if !small.Equal(Of(1)) || large.Len() != 7 {
+		t.Error("SubtractAll() modified its arguments")
+	}
+	s.SubtractAll(s.Clone())
+	if s.Len() != 0 {
+		t.Errorf("SubtractAll() of itself = %v, want {}", s)
+	}
+}
+
+func TestSet_ValuesCollect(t *testing.T) {
+	s := Of(1, 2, 3)
+	if got := Collect(s.Values()); !got.Equal(s) {
+		t.Errorf("Collect(Values()) = %v, want %v", got, s)
+	}
+	if got := Collect(slices.Values([]int{1, 1, 2})); !got.Equal(Of(1, 2)) {
+		t.Errorf("Collect() = %v, want {1, 2}", got)
+	}
+	if got := Collect(Empty[int]().Values()); got.Len() != 0 || got.m == nil {
+		t.Errorf("Collect() of nothing = %v, want an allocated empty set", got)
+	}
+	even := func(v int) bool { return v%2 == 0 }
+	filtered := func(yield func(int) bool) {
+		for v := range Of(1, 2, 3, 4).Values() {
+			if even(v) && !yield(v) {
+				return
+			}
+		}
+	}
+	if got, want := Collect(filtered), Of(1, 2, 3, 4).Filter(even); !got.Equal(want) {
+		t.Errorf("Collect(filtered Values()) = %v, want %v", got, want)
+	}
+}
+
+func TestSet_WithWithout(t *testing.T) {
+	s := Empty[int]().With(1, 2, 3).Without(2)
+	if !s.Equal(Of(1, 3)) {
+		t.Errorf("With().Without() = %v, want {1, 3}", s)
+	}
+
+	var z Set[int]
+	w := z.With(1)
+	if !w.Equal(Of(1)) || z.Len() != 0 {
+		t.Errorf("With() on a zero value = %v and left %v", w, z)
+	}
+	// With and Without write to the map shared with the receiver.
+	r := Of(1)
+	if w := r.With(2); !w.Equal(Of(1, 2)) || !r.Equal(Of(1, 2)) {
+		t.Errorf("With() = %v and left receiver %v, want {1, 2} for both", w, r)
+	}
+	if w := r.Without(1); !w.Equal(Of(2)) || !r.Equal(Of(2)) {
+		t.Errorf("Without() = %v and left receiver %v, want {2} for both", w, r)
+	}
+}
+
+func TestConvertNumeric(t *testing.T) {
+	got := ConvertNumeric(Of(1.2, 1.7, 2.5), func(v float64) int { return int(v) })
+	if !got.Equal(Of(1, 2)) {
+		t.Errorf("ConvertNumeric() = %v, want {1, 2}", got)
+	}
+
+	type celsius float64
+	temps := ConvertNumeric(Of[int8](-5, 20), func(v int8) celsius { return celsius(v) })
+	if !temps.Equal(Of[celsius](-5, 20)) {
+		t.Errorf("ConvertNumeric() = %v, want {-5, 20}", temps)
+	}
+	floats := ConvertNumeric(Of(1, 2), func(v int) float64 { return float64(v) })
+	if !floats.Equal(Of(1.0, 2.0)) {
+		t.Errorf("ConvertNumeric() = %v, want {1, 2}", floats)
+	}
+}
+
+func TestDisjoint(t *testing.T) {
+	tests := []struct {
+		sets []Set[int]
+		want bool
+	}{
+		{[]Set[int]{Of(1, 2), Of(3), Of(4, 5)}, true},
+		{[]Set[int]{Of(1, 2), Of(3), Of(2, 5)}, false},
+		{[]Set[int]{Of(1)}, true},
+		{nil, true},
+		{[]Set[int]{Empty[int](), Empty[int]()}, true},
+	}
+	for _, tt := range tests {
+		if got := Disjoint(tt.sets...); got != tt.want {
+			t.Errorf("Disjoint(%v) = %v, want %v", tt.sets, got, tt.want)
+		}
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	tests := []struct {
+		a, b Set[string]
+		want bool
+	}{
+		{Of("Go", "Rust"), Of("go", "RUST"), true},
+		{Of("Go", "GO"), Of("go"), true},
+		{Of("Go"), Of("Java"), false},
+		{Of("straße"), Of("STRASSE"), false},
+		{Of("K"), Of("K"), true},
+		{Empty[string](), Empty[string](), true},
+	}
+	for _, tt := range tests {
+		if got := EqualFold(tt.a, tt.b); got != tt.want {
+			t.Errorf("EqualFold(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSet_Find(t *testing.T) {
+	s := Of(1, 2, 3, 4)
+	if v, ok := s.Find(func(v int) bool { return v > 3 }); !ok || v != 4 {
+		t.Errorf("Find() = %v, %v, want 4, true", v, ok)
+	}
+	if v, ok := s.Find(func(v int) bool { return v > 2 }); !ok || v < 3 {
+		t.Errorf("Find() = %v, %v, want 3 or 4", v, ok)
+	}
+	if v, ok := s.Find(func(v int) bool { return v > 9 }); ok || v != 0 {
+		t.Errorf("Find() = %v, %v, want 0, false", v, ok)
+	}
+}
+
+func TestSet_SeqOperationsConsumeOnce(t *testing.T) {
+	calls := 0
+	seq := func(yield func(int) bool) {
+		calls++
+		for _, v := range []int{2, 4, 2} {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	s := Of(1, 2, 3)
+	if got := s.UnionSeq(seq); !got.Equal(Of(1, 2, 3, 4)) {
+		t.Errorf("UnionSeq() = %v, want {1, 2, 3, 4}", got)
+	}
+	if got := s.IntersectSeq(seq); !got.Equal(Of(2)) {
+		t.Errorf("IntersectSeq() = %v, want {2}", got)
+	}
+	if calls != 2 {
+		t.Errorf("seq was ranged over %d times, want 2", calls)
+	}
+}
+
+func TestSet_ClearInPlace(t *testing.T) {
+	s := Of(1, 2, 3)
+	shared := s
+	s.ClearInPlace()
+	if s.Len() != 0 || shared.Len() != 0 {
+		t.Errorf("ClearInPlace() left %v", s)
+	}
+	s.Append(4)
+	if !shared.Contains(4) {
+		t.Error("ClearInPlace() must keep the internal map")
+	}
+}
+
+func BenchmarkSet_ClearInPlaceRefill(b *testing.B) {
+	values := sequence(1000)
+	s := FromSlice(values)
+	b.ReportAllocs()
+	for range b.N {
+		s.ClearInPlace()
+		s.Append(values...)
+	}
+}
+
+func BenchmarkSet_ClearRefill(b *testing.B) {
+	values := sequence(1000)
+	s := FromSlice(values)
+	b.ReportAllocs()
+	for range b.N {
+		s.Clear()
+		s.Append(values...)
+	}
+}
+
+func TestIntersectionAll(t *testing.T) {
+	sets := []Set[int]{Of(1, 2, 3, 4), Of(2, 3), Of(3, 2, 9)}
+	if got := IntersectionAll(sets); !got.Equal(Of(2, 3)) {
+		t.Errorf("IntersectionAll() = %v, want {2, 3}", got)
+	}
+	if !sets[1].Equal(Of(2, 3)) || sets[0].Len() != 4 {
+		t.Error("IntersectionAll() must not modify or reorder its input")
+	}
+	if got := IntersectionAll([]Set[int]{Of(1), Empty[int](), Of(1)}); got.Len() != 0 {
+		t.Errorf("IntersectionAll() with an empty set = %v, want {}", got)
+	}
+	if got := IntersectionAll[int](nil); got.Len() != 0 {
+		t.Errorf("IntersectionAll(nil) = %v, want {}", got)
+	}
+}
+
+func benchmarkIntersectionSets() []Set[int] {
+	sets := make([]Set[int], 8)
+	for i := range sets {
+		sets[i] = FromSlice(sequence(10000))
+	}
+	sets[len(sets)-1] = Of(1, 2, 3)
+	return sets
+}
+
+func BenchmarkIntersectionAll(b *testing.B) {
+	sets := benchmarkIntersectionSets()
+	b.ReportAllocs()
+	for range b.N {
+		IntersectionAll(sets)
+	}
+}
+
+func BenchmarkIntersectionInOrder(b *testing.B) {
+	sets := benchmarkIntersectionSets()
+	b.ReportAllocs()
+	for range b.N {
+		acc := sets[0].Clone()
+		for _, s := range sets[1:] {
+			acc.RetainAll(s)
+		}
+	}
+}
+
+func TestCloneFunc(t *testing.T) {
+	type node struct{ name string }
+	a, b := &node{"a"}, &node{"b"}
+	s := Of(a, b)
+
+	shallow := s.Clone()
+	deep := CloneFunc(s, func(n *node) *node {
+		c := *n
+		return &c
+	})
+	if !shallow.Contains(a) || deep.Contains(a) || deep.Len() != 2 {
+		t.Error("CloneFunc() must copy the elements")
+	}
+	for n := range deep.All() {
+		n.name += "!"
+	}
+	if a.name != "a" || b.name != "b" {
+		t.Error("mutating deep copies must not affect the originals")
+	}
+}
+
+func TestSet_CountPresent(t *testing.T) {
+	s := Of(1, 2, 3)
+	if got := s.CountPresent(1, 1, 4, 3); got != 3 {
+		t.Errorf("CountPresent() = %d, want 3", got)
+	}
+	if got := s.CountPresent(); got != 0 {
+		t.Errorf("CountPresent() = %d, want 0", got)
+	}
+	if got := s.CountPresent(3, 2, 1); got != 3 {
+		t.Errorf("CountPresent() with all present = %d, want 3", got)
+	}
+	if got := s.CountPresent(4, 5); got != 0 {
+		t.Errorf("CountPresent() with none present = %d, want 0", got)
+	}
+}
+
+func TestSet_SampleWeighted(t *testing.T) {
+	s := Of("a", "b", "c", "zero")
+	weight := func(v string) float64 {
+		if v == "zero" {
+			return 0
+		}
+		return 1
+	}
+	r := rand.New(rand.NewSource(1))
+	got := s.SampleWeighted(weight, 10, r)
+	if len(got) != 3 || FromSlice(got).Contains("zero") {
+		t.Errorf("SampleWeighted() = %v, want a, b and c", got)
+	}
+	if got := s.SampleWeighted(weight, -1, r); len(got) != 0 {
+		t.Errorf("SampleWeighted(-1) = %v, want []", got)
+	}
+}
+
+func TestSet_SampleWeightedDistribution(t *testing.T) {
+	s := Of("heavy", "light")
+	weight := func(v string) float64 {
+		if v == "heavy" {
+			return 9
+		}
+		return 1
+	}
+	r := rand.New(rand.NewSource(1))
+	heavy := 0
+	const n = 10000
+	for range n {
+		if s.SampleWeighted(weight, 1, r)[0] == "heavy" {
+			heavy++
+		}
+	}
+	if p := float64(heavy) / n; p < 0.85 || p > 0.95 {
+		t.Errorf("heavy was chosen with probability %v, want about 0.9", p)
+	}
+}
+
+func TestSet_Grow(t *testing.T) {
+	s := Of(-1)
+	s.Grow(0)
+	s.Grow(100)
+	if !s.Equal(Of(-1)) {
+		t.Errorf("Grow() changed the elements: %v", s)
+	}
+
+	var z Set[int]
+	z.Grow(10)
+	if z.m == nil || z.Len() != 0 {
+		t.Error("Grow() on a zero value must allocate an empty map")
+	}
+}
+
+func TestSet_GrowDetachesCopies(t *testing.T) {
+	a := Of(1)
+	b := a
+	b.Grow(10)
+	b.Append(2)
+	if a.Contains(2) || !b.Equal(Of(1, 2)) {
+		t.Errorf("after Grow() a = %v, b = %v, want {1} and {1, 2}", a, b)
+	}
+
+	b.Grow(0)
+	c := b
+	c.Append(3)
+	if !b.Contains(3) {
+		t.Error("Grow(0) must keep the internal map")
+	}
+}
+
+func TestSet_GrowNegativePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Grow(-1) did not panic")
+		}
+	}()
+	var s Set[int]
+	s.Grow(-1)
+}
+
+func TestSet_GrowAllocs(t *testing.T) {
+	values := sequence(1000)
+	var s Set[int]
+	allocs := testing.AllocsPerRun(10, func() {
+		s = Set[int]{}
+		s.Grow(len(values))
+	})
+	inserts := testing.AllocsPerRun(10, func() {
+		s = Set[int]{}
+		s.Grow(len(values))
+		s.Append(values...)
+	})
+	if inserts != allocs {
+		t.Errorf("inserting %d values after Grow allocated %v times, want no allocations beyond Grow's %v", len(values), inserts-allocs, allocs)
+	}
+}
+
+func TestSet_DiffCounts(t *testing.T) {
+	tests := []struct {
+		before, after  Set[int]
+		added, removed int
+	}{
+		{Empty[int](), Empty[int](), 0, 0},
+		{Empty[int](), Of(1), 1, 0},
+		{Of(1), Empty[int](), 0, 1},
+		{Of(1), Of(1), 0, 0},
+		{Of(1), Of(2), 1, 1},
+		{Of(1, 2, 3), Of(2, 3, 4, 5), 2, 1},
+	}
+	for _, tt := range tests {
+		added, removed := tt.before.DiffCounts(tt.after)
+		if added != tt.added || removed != tt.removed {
+			t.Errorf("%v.DiffCounts(%v) = %d, %d, want %d, %d", tt.before, tt.after, added, removed, tt.added, tt.removed)
+		}
+		a, r := tt.before.Diff(tt.after)
+		if added != a.Len() || removed != r.Len() {
+			t.Errorf("%v.DiffCounts(%v) must agree with Diff()", tt.before, tt.after)
+		}
+	}
+
+	before, after := Of(1, 2, 3), Of(2, 3, 4, 5)
+	if n := testing.AllocsPerRun(10, func() { before.DiffCounts(after) }); n != 0 {
+		t.Errorf("DiffCounts() allocated %v times, want 0", n)
+	}
+}
+
+func TestSet_AddAll(t *testing.T) {
+	s := Of(1)
+	if n := s.AddAll(1, 2, 3, 2); n != 2 {
+		t.Errorf("AddAll() = %d, want 2", n)
+	}
+	if !s.Equal(Of(1, 2, 3)) {
+		t.Errorf("AddAll() = %v, want {1, 2, 3}", s)
+	}
+	var z Set[int]
+	if n := z.AddAll(); n != 0 || z.Len() != 0 {
+		t.Errorf("AddAll() of nothing = %d, want 0", n)
+	}
+}
+
+func TestSet_Chunk(t *testing.T) {
+	s := FromSlice(sequence(10))
+	chunks := s.Chunk(4)
+	if len(chunks) != 3 {
+		t.Fatalf("len(Chunk(4)) = %d, want 3", len(chunks))
+	}
+	sizes := []int{chunks[0].Len(), chunks[1].Len(), chunks[2].Len()}
+	if !slices.Equal(sizes, []int{4, 4, 2}) {
+		t.Errorf("chunk sizes = %v, want [4 4 2]", sizes)
+	}
+	if !Union(chunks...).Equal(s) || !Disjoint(chunks...) {
+		t.Error("chunks must partition s")
+	}
+	if got := s.Chunk(10); len(got) != 1 || !got[0].Equal(s) {
+		t.Errorf("Chunk(10) = %v, want [s]", got)
+	}
+}
+
+func TestSet_ChunkLargeSize(t *testing.T) {
+	s := Of(1, 2)
+	for _, size := range []int{3, math.MaxInt} {
+		if got := s.Chunk(size); len(got) != 1 || !got[0].Equal(s) {
+			t.Errorf("Chunk(%d) = %v, want [s]", size, got)
+		}
+	}
+	if got := Empty[int]().Chunk(math.MaxInt); len(got) != 0 {
+		t.Errorf("Chunk() of empty set = %v, want []", got)
+	}
+}
+
+func TestSet_ChunkInvalidSizePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunk(0) did not panic")
+		}
+	}()
+	Of(1).Chunk(0)
+}
+
+func TestSet_SliceSeeded(t *testing.T) {
+	identity := func(v int) uint64 { return uint64(v) }
+	a := FromSlice(sequence(100))
+	b := Empty[int]()
+	for i := 99; i >= 0; i-- {
+		b.Append(i)
+	}
+
+	got := a.SliceSeeded(1, identity)
+	if !slices.Equal(got, b.SliceSeeded(1, identity)) {
+		t.Error("equal sets and seeds must produce the same order")
+	}
+	if !FromSlice(got).Equal(a) || len(got) != a.Len() {
+		t.Error("SliceSeeded() must contain every element exactly once")
+	}
+	if slices.Equal(got, a.SliceSeeded(2, identity)) {
+		t.Error("different seeds should produce different orders")
+	}
+	if slices.IsSorted(got) {
+		t.Error("SliceSeeded() should not preserve the order of the hashes")
+	}
+}
+
+func TestSet_SymmetricDifferenceInPlace(t *testing.T) {
+	s := Of(1, 2, 3)
+	o := Of(2, 3, 4)
+	s.SymmetricDifferenceInPlace(o)
+	if !s.Equal(Of(1, 4)) {
+		t.Errorf("SymmetricDifferenceInPlace() = %v, want {1, 4}", s)
+	}
+	if !o.Equal(Of(2, 3, 4)) {
+		t.Errorf("SymmetricDifferenceInPlace() modified its argument: %v", o)
+	}
+
+	s.SymmetricDifferenceInPlace(s)
+	if s.Len() != 0 {
+		t.Errorf("SymmetricDifferenceInPlace() with itself = %v, want {}", s)
+	}
+
+	var z Set[int]
+	z.SymmetricDifferenceInPlace(Of(5))
+	if !z.Equal(Of(5)) {
+		t.Errorf("SymmetricDifferenceInPlace() on a zero value = %v, want {5}", z)
+	}
+}
+
+func TestSet_MarshalJSONIndent(t *testing.T) {
+	b, err := Of(1).MarshalJSONIndent("", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "[\n  1\n]" {
+		t.Errorf("MarshalJSONIndent() = %q, want %q", b, "[\n  1\n]")
+	}
+	var s Set[int]
+	if err := json.Unmarshal(b, &s); err != nil || !s.Equal(Of(1)) {
+		t.Errorf("Unmarshal(MarshalJSONIndent()) = %v, %v, want {1}", s, err)
+	}
+	if b, _ := Empty[int]().MarshalJSONIndent(">", "\t"); string(b) != "[]" {
+		t.Errorf("MarshalJSONIndent() of empty set = %q, want %q", b, "[]")
+	}
+}
+
+func TestContainsByAppendBy(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+	userID := func(u user) int { return u.id }
+
+	var ids Set[int]
+	AppendBy(&ids, userID, user{1, "ann"}, user{2, "bob"}, user{1, "ann again"})
+	if !ids.Equal(Of(1, 2)) {
+		t.Errorf("AppendBy() = %v, want {1, 2}", ids)
+	}
+	if !ContainsBy(ids, user{2, "someone"}, userID) || ContainsBy(ids, user{3, "eve"}, userID) {
+		t.Error("ContainsBy() must test the membership of the key")
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	tests := []struct {
+		a, b Set[float64]
+		eps  float64
+		want bool
+	}{
+		{Of(1.0, 2.0), Of(1.05, 1.95), 0.1, true},
+		{Of(1.0, 2.0), Of(1.2, 2.0), 0.1, false},
+		{Of(1.0, 2.0), Of(1.0), 0.1, false},
+		{Of(1.0, 1.1), Of(1.05, 1.15), 0.06, true},
+		{Of(math.NaN()), Of(math.NaN()), 1, false},
+		{Empty[float64](), Empty[float64](), 0, true},
+	}
+	for _, tt := range tests {
+		if got := EqualApprox(tt.a, tt.b, tt.eps); got != tt.want {
+			t.Errorf("EqualApprox(%v, %v, %v) = %v, want %v", tt.a, tt.b, tt.eps, got, tt.want)
+		}
+	}
+}
+
+func TestFrequency(t *testing.T) {
+	got := Frequency(Of("a", "b"), Of("b", "c"), Of("b"))
+	want := map[string]int{"a": 1, "b": 3, "c": 1}
+	if !maps.Equal(got, want) {
+		t.Errorf("Frequency() = %v, want %v", got, want)
+	}
+	if got := Frequency[int](); len(got) != 0 {
+		t.Errorf("Frequency() of no sets = %v, want empty", got)
+	}
+}
+
+func TestSet_SliceInto(t *testing.T) {
+	dst := []int{-1}
+	got := Of(1, 2).SliceInto(dst)
+	if len(got) != 3 || got[0] != -1 {
+		t.Fatalf("SliceInto() = %v, want -1 followed by the elements", got)
+	}
+	slices.Sort(got[1:])
+	if !slices.Equal(got, []int{-1, 1, 2}) {
+		t.Errorf("SliceInto() = %v, want [-1 1 2]", got)
+	}
+}
+
+func TestSet_SliceIntoAllocs(t *testing.T) {
+	s := FromSlice(sequence(100))
+	buf := make([]int, 0, s.Len())
+	if n := testing.AllocsPerRun(10, func() { buf = s.SliceInto(buf[:0]) }); n != 0 {
+		t.Errorf("SliceInto() with enough capacity allocated %v times, want 0", n)
+	}
+}
+
+func TestSet_SortedSliceForGoldenTests(t *testing.T) {
+	a := FromSlice(sequence(50))
+	b := Empty[int]()
+	for i := 49; i >= 0; i-- {
+		b.Append(i)
+	}
+	if !slices.Equal(Sorted(a), Sorted(b)) {
+		t.Error("equal sets must produce identical sorted slices")
+	}
+}
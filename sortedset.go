@@ -0,0 +1,110 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// SortedSet is a data structure that contains a set of ordered elements and
+// keeps them sorted in ascending order. Unlike Set, it supports ordered range
+// queries. It is implemented using a sorted slice, so lookups take
+// logarithmic time while insertions and deletions take linear time.
+type SortedSet[T cmp.Ordered] struct {
+	// values contains the elements of the set in ascending order.
+	values []T
+}
+
+// EmptySorted initializes a new SortedSet without any elements inside it.
+func EmptySorted[T cmp.Ordered]() *SortedSet[T] {
+	return &SortedSet[T]{}
+}
+
+// SortedOf initializes a new SortedSet and appends the given values to it.
+func SortedOf[T cmp.Ordered](values ...T) *SortedSet[T] {
+	s := &SortedSet[T]{values: slices.Clone(values)}
+	slices.Sort(s.values)
+	s.values = slices.Compact(s.values)
+	return s
+}
+
+// Len returns the number of elements that s contains.
+func (s *SortedSet[T]) Len() int {
+	return len(s.values)
+}
+
+// Contains reports whether s contains val.
+func (s *SortedSet[T]) Contains(val T) bool {
+	_, ok := slices.BinarySearch(s.values, val)
+	return ok
+}
+
+// Append adds the values to s. If any value is already present, the value does
+// not impact the set.
+func (s *SortedSet[T]) Append(values ...T) {
+	for _, v := range values {
+		if i, ok := slices.BinarySearch(s.values, v); !ok {
+			s.values = slices.Insert(s.values, i, v)
+		}
+	}
+}
+
+// Delete removes the elements of values from s.
+func (s *SortedSet[T]) Delete(values ...T) {
+	for _, v := range values {
+		if i, ok := slices.BinarySearch(s.values, v); ok {
+			s.values = slices.Delete(s.values, i, i+1)
+		}
+	}
+}
+
+// Clear removes all elements from s.
+func (s *SortedSet[T]) Clear() {
+	s.values = nil
+}
+
+// Slice converts s into a slice whose elements are in ascending order.
+func (s *SortedSet[T]) Slice() []T {
+	return append(make([]T, 0, len(s.values)), s.values...)
+}
+
+// All returns an iterator over the elements of s in ascending order.
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return slices.Values(s.values)
+}
+
+// Range returns the elements of s that are greater than or equal to lo and
+// less than hi, in ascending order.
+func (s *SortedSet[T]) Range(lo, hi T) []T {
+	i, _ := slices.BinarySearch(s.values, lo)
+	j, _ := slices.BinarySearch(s.values, hi)
+	if i >= j {
+		return []T{}
+	}
+	return slices.Clone(s.values[i:j])
+}
+
+// Floor returns the largest element of s that is less than or equal to val.
+// The boolean reports whether such an element exists.
+func (s *SortedSet[T]) Floor(val T) (T, bool) {
+	i, ok := slices.BinarySearch(s.values, val)
+	if ok {
+		return s.values[i], true
+	}
+	if i == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.values[i-1], true
+}
+
+// Ceil returns the smallest element of s that is greater than or equal to
+// val. The boolean reports whether such an element exists.
+func (s *SortedSet[T]) Ceil(val T) (T, bool) {
+	i, _ := slices.BinarySearch(s.values, val)
+	if i == len(s.values) {
+		var zero T
+		return zero, false
+	}
+	return s.values[i], true
+}
@@ -0,0 +1,72 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortedSet(t *testing.T) {
+	s := SortedOf(5, 1, 3, 3)
+	s.Append(4, 2, 4)
+	s.Delete(3, 9)
+	if got := s.Slice(); !slices.Equal(got, []int{1, 2, 4, 5}) {
+		t.Errorf("Slice() = %v, want [1 2 4 5]", got)
+	}
+	if s.Len() != 4 || !s.Contains(4) || s.Contains(3) {
+		t.Errorf("Len() = %d, want 4", s.Len())
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{1, 2, 4, 5}) {
+		t.Errorf("All() yielded %v, want [1 2 4 5]", got)
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", s.Len())
+	}
+}
+
+func TestSortedSet_Range(t *testing.T) {
+	s := SortedOf(10, 20, 30, 40)
+	tests := []struct {
+		lo, hi int
+		want   []int
+	}{
+		{20, 40, []int{20, 30}},
+		{15, 35, []int{20, 30}},
+		{0, 100, []int{10, 20, 30, 40}},
+		{40, 20, []int{}},
+		{25, 26, []int{}},
+	}
+	for _, tt := range tests {
+		if got := s.Range(tt.lo, tt.hi); !slices.Equal(got, tt.want) {
+			t.Errorf("Range(%d, %d) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func TestSortedSet_FloorCeil(t *testing.T) {
+	s := SortedOf(10, 20, 30)
+	tests := []struct {
+		val               int
+		floor, ceil       int
+		hasFloor, hasCeil bool
+	}{
+		{5, 0, 10, false, true},
+		{10, 10, 10, true, true},
+		{15, 10, 20, true, true},
+		{30, 30, 30, true, true},
+		{35, 30, 0, true, false},
+	}
+	for _, tt := range tests {
+		if v, ok := s.Floor(tt.val); v != tt.floor || ok != tt.hasFloor {
+			t.Errorf("Floor(%d) = %d, %v, want %d, %v", tt.val, v, ok, tt.floor, tt.hasFloor)
+		}
+		if v, ok := s.Ceil(tt.val); v != tt.ceil || ok != tt.hasCeil {
+			t.Errorf("Ceil(%d) = %d, %v, want %d, %v", tt.val, v, ok, tt.ceil, tt.hasCeil)
+		}
+	}
+
+	var empty SortedSet[int]
+	if _, ok := empty.Floor(1); ok {
+		t.Error("Floor() on an empty set must report false")
+	}
+}
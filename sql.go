@@ -0,0 +1,34 @@
+package set
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+var (
+	_ driver.Valuer = (*Set[int])(nil)
+	_ sql.Scanner   = (*Set[int])(nil)
+)
+
+// Value returns s encoded as a JSON array, so that s can be stored in a single
+// database column.
+func (s Set[T]) Value() (driver.Value, error) {
+	return s.MarshalJSON()
+}
+
+// Scan decodes a JSON array into s. A nil src results in an empty set. The
+// previous content of s is cleared.
+func (s *Set[T]) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		s.Clear()
+		return nil
+	case []byte:
+		return s.UnmarshalJSON(src)
+	case string:
+		return s.UnmarshalJSON([]byte(src))
+	default:
+		return &DecodeError{Format: "sql", Index: -1, Err: fmt.Errorf("unsupported source type %T", src)}
+	}
+}
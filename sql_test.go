@@ -0,0 +1,37 @@
+package set
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSet_SQLRoundTrip(t *testing.T) {
+	v, err := Of(1, 2, 3).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, src := range []any{v, string(v.([]byte))} {
+		var s Set[int]
+		if err := s.Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		if !s.Equal(Of(1, 2, 3)) {
+			t.Errorf("Scan(%v) = %v, want {1, 2, 3}", src, s)
+		}
+	}
+}
+
+func TestSet_ScanNil(t *testing.T) {
+	s := Of(1)
+	if err := s.Scan(nil); err != nil || s.Len() != 0 {
+		t.Errorf("Scan(nil) = %v, %v, want {}", s, err)
+	}
+}
+
+func TestSet_ScanUnsupported(t *testing.T) {
+	var s Set[int]
+	var de *DecodeError
+	if err := s.Scan(42); !errors.As(err, &de) || de.Format != "sql" {
+		t.Errorf("Scan(42) error = %v, want sql DecodeError", err)
+	}
+}
@@ -0,0 +1,84 @@
+package set
+
+import "sync"
+
+// SyncSet is a Set that is safe for concurrent use by multiple goroutines. It
+// guards an internal Set with a read-write mutex.
+type SyncSet[T comparable] struct {
+	// mu guards s. Reads acquire the read lock, writes the write lock.
+	mu sync.RWMutex
+
+	// s is the guarded set.
+	s Set[T]
+}
+
+// EmptySync initializes a new SyncSet without any elements inside it.
+func EmptySync[T comparable]() *SyncSet[T] {
+	return &SyncSet[T]{s: Empty[T]()}
+}
+
+// SyncOf initializes a new SyncSet and appends the given values to it.
+func SyncOf[T comparable](values ...T) *SyncSet[T] {
+	return &SyncSet[T]{s: Of(values...)}
+}
+
+// Len returns the number of elements that s contains.
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Len()
+}
+
+// Contains reports whether s contains val.
+func (s *SyncSet[T]) Contains(val T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Contains(val)
+}
+
+// Append adds the values to s. If any value is already present, the value does
+// not impact the set.
+func (s *SyncSet[T]) Append(values ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Append(values...)
+}
+
+// GetOrAdd adds val to s if it is not already present and reports whether it
+// was newly added. The check and the insertion happen under a single lock
+// acquisition, so among goroutines racing to add the same value exactly one
+// observes true.
+func (s *SyncSet[T]) GetOrAdd(val T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Add(val)
+}
+
+// Delete removes the elements of values from s.
+func (s *SyncSet[T]) Delete(values ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Delete(values...)
+}
+
+// Clear removes all elements from s.
+func (s *SyncSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Clear()
+}
+
+// Slice converts s into a slice.
+func (s *SyncSet[T]) Slice() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Slice()
+}
+
+// Snapshot returns a point-in-time copy of the elements of s. The returned set
+// is independent of s and can be used without holding any lock.
+func (s *SyncSet[T]) Snapshot() Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Clone()
+}
@@ -0,0 +1,104 @@
+package set
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncSet_Concurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 1000
+	s := EmptySync[int]()
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range perGoroutine {
+				s.Append(g*perGoroutine + i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := range perGoroutine {
+				s.Contains(i)
+				s.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := s.Len(); n != goroutines*perGoroutine {
+		t.Errorf("Len() = %d, want %d", n, goroutines*perGoroutine)
+	}
+}
+
+func TestSyncSet_Operations(t *testing.T) {
+	s := SyncOf(1, 2, 3)
+	s.Delete(2)
+	if !s.Contains(1) || s.Contains(2) || len(s.Slice()) != 2 {
+		t.Errorf("Slice() = %v, want [1 3] in any order", s.Slice())
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", s.Len())
+	}
+}
+
+func TestSyncSet_SnapshotConcurrent(t *testing.T) {
+	s := SyncOf(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 1000; i++ {
+			s.Append(i)
+			s.Delete(i - 1)
+		}
+	}()
+
+	for range 100 {
+		snap := s.Snapshot()
+		if snap.Len() > 2 {
+			t.Errorf("Snapshot() = %v, want at most 2 elements", snap)
+		}
+		snap.Append(-1)
+		if s.Contains(-1) {
+			t.Fatal("mutating a snapshot must not affect the SyncSet")
+		}
+	}
+	<-done
+
+	if snap := s.Snapshot(); !snap.Equal(Of(1000)) {
+		t.Errorf("Snapshot() = %v, want {1000}", snap)
+	}
+}
+
+func TestSyncSet_GetOrAddConcurrent(t *testing.T) {
+	const goroutines = 32
+	s := EmptySync[string]()
+	var (
+		wg    sync.WaitGroup
+		added atomic.Int32
+		start = make(chan struct{})
+	)
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if s.GetOrAdd("key") {
+				added.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if n := added.Load(); n != 1 {
+		t.Errorf("GetOrAdd() reported true %d times, want exactly once", n)
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", s.Len())
+	}
+}
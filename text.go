@@ -0,0 +1,118 @@
+package set
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Set[int])(nil)
+	_ encoding.TextUnmarshaler = (*Set[int])(nil)
+)
+
+// MarshalText marshals s into a comma-separated list of its elements.
+//
+// Elements whose underlying type is string are written as is, elements implementing
+// encoding.TextMarshaler are written using MarshalText and all other elements
+// are written as JSON. Within an element, every backslash is escaped as `\\`
+// and every comma as `\,`. The elements are sorted by their escaped text, so
+// equal sets always produce the same output. The empty set is marshaled into
+// an empty text, which means a set whose only element encodes to an empty text
+// cannot be told apart from the empty set.
+func (s Set[T]) MarshalText() ([]byte, error) {
+	elems := make([]string, 0, len(s.m))
+	for v := range s.m {
+		text, err := marshalElemText(v)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, escapeElemText(text))
+	}
+	sort.Strings(elems)
+	return []byte(strings.Join(elems, ",")), nil
+}
+
+// UnmarshalText unmarshals a comma-separated list of elements, in the format
+// written by MarshalText, into s. The previous content of s is cleared.
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	s.Clear()
+	if len(text) == 0 {
+		return nil
+	}
+	elems, err := splitElemTexts(string(text))
+	if err != nil {
+		return &DecodeError{Format: "text", Index: -1, Err: err}
+	}
+	for i, elem := range elems {
+		var v T
+		if err := unmarshalElemText(elem, &v); err != nil {
+			return &DecodeError{Format: "text", Index: i, Err: err}
+		}
+		s.Append(v)
+	}
+	return nil
+}
+
+// marshalElemText returns the unescaped text form of a single element.
+func marshalElemText(v any) (string, error) {
+	switch v := v.(type) {
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		return string(text), err
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+			return rv.String(), nil
+		}
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
+
+// unmarshalElemText parses the unescaped text form of a single element into
+// v.
+func unmarshalElemText(text string, v any) error {
+	switch v := v.(type) {
+	case encoding.TextUnmarshaler:
+		return v.UnmarshalText([]byte(text))
+	default:
+		if rv := reflect.ValueOf(v).Elem(); rv.Kind() == reflect.String {
+			rv.SetString(text)
+			return nil
+		}
+		return json.Unmarshal([]byte(text), v)
+	}
+}
+
+// escapeElemText escapes backslashes and commas in text.
+func escapeElemText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `,`, `\,`).Replace(text)
+}
+
+// splitElemTexts splits text at every unescaped comma and unescapes the
+// resulting elements.
+func splitElemTexts(text string) ([]string, error) {
+	var (
+		elems []string
+		elem  strings.Builder
+	)
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '\\':
+			i++
+			if i == len(text) || (text[i] != '\\' && text[i] != ',') {
+				return nil, errors.New("invalid escape sequence")
+			}
+			elem.WriteByte(text[i])
+		case ',':
+			elems = append(elems, elem.String())
+			elem.Reset()
+		default:
+			elem.WriteByte(c)
+		}
+	}
+	return append(elems, elem.String()), nil
+}
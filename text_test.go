@@ -0,0 +1,76 @@
+package set
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSet_MarshalText(t *testing.T) {
+	tests := []struct {
+		s    Set[string]
+		want string
+	}{
+		{Empty[string](), ""},
+		{Of("b", "a"), "a,b"},
+		{Of(`a,b`, `c\d`), `a\,b,c\\d`},
+	}
+	for _, tt := range tests {
+		got, err := tt.s.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("MarshalText() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSet_TextRoundTrip(t *testing.T) {
+	strs := Of("", "x", `a,b`, `\`, `\,`)
+	text, err := strs.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotStrs Set[string]
+	if err := gotStrs.UnmarshalText(text); err != nil || !gotStrs.Equal(strs) {
+		t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, gotStrs, err, strs)
+	}
+
+	ints := Of(-1, 0, 42)
+	text, err = ints.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotInts Set[int]
+	if err := gotInts.UnmarshalText(text); err != nil || !gotInts.Equal(ints) {
+		t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, gotInts, err, ints)
+	}
+}
+
+func TestSet_UnmarshalTextError(t *testing.T) {
+	var de *DecodeError
+	var s Set[int]
+	if err := s.UnmarshalText([]byte(`1,x`)); !errors.As(err, &de) || de.Index != 1 {
+		t.Errorf("UnmarshalText() error = %v, want DecodeError at index 1", err)
+	}
+	var strs Set[string]
+	if err := strs.UnmarshalText([]byte(`a\b`)); !errors.As(err, &de) || de.Index != -1 {
+		t.Errorf("UnmarshalText() error = %v, want DecodeError for invalid escape", err)
+	}
+}
+
+func TestSet_TextNamedString(t *testing.T) {
+	type ID string
+	ids := Of[ID]("a b", `"q"`)
+	text, err := ids.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"q",a b`; string(text) != want {
+		t.Errorf("MarshalText() = %q, want %q", text, want)
+	}
+	var got Set[ID]
+	if err := got.UnmarshalText(text); err != nil || !got.Equal(ids) {
+		t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, got, err, ids)
+	}
+}
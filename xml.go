@@ -0,0 +1,54 @@
+package set
+
+import "encoding/xml"
+
+var (
+	_ xml.Marshaler   = (*Set[int])(nil)
+	_ xml.Unmarshaler = (*Set[int])(nil)
+)
+
+// xmlItem is the name of the XML element that holds a single element of a set.
+var xmlItem = xml.StartElement{Name: xml.Name{Local: "item"}}
+
+// MarshalXML marshals s into the element start that contains one <item>
+// element per element of s.
+func (s Set[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for v := range s.m {
+		if err := e.EncodeElement(v, xmlItem); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML unmarshals the <item> elements inside the element start into
+// s. Other child elements are skipped. The previous content of s is cleared.
+func (s *Set[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s.Clear()
+	for i := 0; ; {
+		tok, err := d.Token()
+		if err != nil {
+			return &DecodeError{Format: "xml", Index: -1, Err: err}
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if tok.Name.Local != xmlItem.Name.Local {
+				if err := d.Skip(); err != nil {
+					return &DecodeError{Format: "xml", Index: -1, Err: err}
+				}
+				continue
+			}
+			var v T
+			if err := d.DecodeElement(&v, &tok); err != nil {
+				return &DecodeError{Format: "xml", Index: i, Err: err}
+			}
+			s.Append(v)
+			i++
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package set
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func TestSet_XMLRoundTrip(t *testing.T) {
+	type doc struct {
+		XMLName xml.Name    `xml:"doc"`
+		Tags    Set[string] `xml:"tags"`
+	}
+	in := doc{Tags: Of("a", "b<c")}
+	data, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := doc{Tags: Of("stale")}
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Tags.Equal(in.Tags) {
+		t.Errorf("Unmarshal(%s) = %v, want %v", data, out.Tags, in.Tags)
+	}
+}
+
+func TestSet_UnmarshalXML(t *testing.T) {
+	var s Set[int]
+	data := `<s><item>1</item><other>x</other><item>2</item></s>`
+	if err := xml.Unmarshal([]byte(data), &s); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(Of(1, 2)) {
+		t.Errorf("Unmarshal() = %v, want {1, 2}", s)
+	}
+
+	var de *DecodeError
+	data = `<s><item>1</item><item>x</item></s>`
+	if err := xml.Unmarshal([]byte(data), &s); !errors.As(err, &de) || de.Index != 1 {
+		t.Errorf("Unmarshal() error = %v, want DecodeError at index 1", err)
+	}
+	s = Of(9)
+	if err := xml.Unmarshal([]byte(`<s><other/></s>`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 0 || s.m == nil {
+		t.Errorf("Unmarshal() without items = %v, want an allocated empty set", s)
+	}
+}